@@ -0,0 +1,346 @@
+package vvalidator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Batch formats.
+const (
+	// BatchCSV is CSV with a header row naming the fields.
+	BatchCSV = "csv"
+	// BatchJSON is JSON objects one after the other, as NDJSON, or in arrays.
+	BatchJSON = "json"
+)
+
+// DefaultBatchSamples is the number of samples kept per error kind when
+// BatchOptions.Samples is 0.
+var DefaultBatchSamples = 5
+
+// BatchOptions configures ValidateBatch.
+type BatchOptions struct {
+	// Format is BatchCSV or BatchJSON.
+	Format string
+	// Workers is the number of goroutines validating rows, 0 or 1 validates
+	// them one by one. The report is in row order either way.
+	Workers int
+	// Samples is the number of errors kept per error kind, 0 means
+	// DefaultBatchSamples and a negative number none.
+	Samples int
+	// Handler receives the invalid rows in row order instead of the report
+	// Rows, so that the errors of large inputs are not held in memory.
+	Handler func(row BatchRow)
+}
+
+// BatchReport summarizes the validation of a batch.
+type BatchReport struct {
+	Records int `json:"records"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+	// Rows are the invalid rows in row order, unless a Handler is set.
+	Rows []BatchRow `json:"rows,omitempty"`
+	// Columns counts the errors by field.
+	Columns map[string]int `json:"columns,omitempty"`
+	// Kinds counts the errors by failed rule, e.g. "required" or "max".
+	Kinds map[string]int `json:"kinds,omitempty"`
+	// Samples are the first errors of each kind.
+	Samples map[string][]BatchSample `json:"samples,omitempty"`
+}
+
+// BatchRow holds the errors of an invalid row, rows are numbered from 1
+// after the CSV header.
+type BatchRow struct {
+	Row    int    `json:"row"`
+	Errors Errors `json:"errors"`
+}
+
+// BatchSample is an error of a row.
+type BatchSample struct {
+	Row   int   `json:"row"`
+	Error Error `json:"error"`
+}
+
+type batchRecord struct {
+	row  int
+	data map[string]string
+	errs Errors
+	err  error
+}
+
+// ValidateBatch validate the records read from r with rule strings keyed by
+// field name, as ValidateRules does. Records are read and validated one at a
+// time, only the report is kept in memory. Reading stops at the first input
+// error, which is returned with the report of the rows before it.
+func (v *Validator) ValidateBatch(r io.Reader, rules map[string]string, opts BatchOptions) (*BatchReport, error) {
+	var read func(io.Reader, func(map[string]string) error) error
+	switch opts.Format {
+	case BatchCSV:
+		read = readCSV
+	case BatchJSON:
+		read = readJSON
+	default:
+		return nil, errors.New("unsupported batch format " + opts.Format)
+	}
+	for _, rule := range rules {
+		if _, err := parseRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Samples == 0 {
+		opts.Samples = DefaultBatchSamples
+	}
+
+	report := &BatchReport{
+		Columns: make(map[string]int),
+		Kinds:   make(map[string]int),
+		Samples: make(map[string][]BatchSample),
+	}
+	if opts.Workers <= 1 {
+		row := 0
+		err := read(r, func(data map[string]string) error {
+			row++
+			rec := &batchRecord{row: row, data: data}
+			v.validateRecord(rec, rules)
+			return report.add(rec, opts)
+		})
+		return report, err
+	}
+	return report, v.validateBatch(read, r, rules, opts, report)
+}
+
+// validateBatch validates the records with a pool of workers, the results
+// are reordered by row before being added to the report.
+func (v *Validator) validateBatch(read func(io.Reader, func(map[string]string) error) error, r io.Reader, rules map[string]string, opts BatchOptions, report *BatchReport) error {
+	jobs := make(chan *batchRecord, opts.Workers)
+	results := make(chan *batchRecord, opts.Workers)
+	// inflight bounds the records read but not yet added to the report.
+	inflight := make(chan struct{}, 4*opts.Workers)
+	stop := make(chan struct{})
+
+	var readErr error
+	go func() {
+		defer close(jobs)
+		row := 0
+		readErr = read(r, func(data map[string]string) error {
+			row++
+			select {
+			case inflight <- struct{}{}:
+			case <-stop:
+				return errStopBatch
+			}
+			jobs <- &batchRecord{row: row, data: data}
+			return nil
+		})
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rec := range jobs {
+				v.validateRecord(rec, rules)
+				results <- rec
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var err error
+	pending := make(map[int]*batchRecord)
+	next := 1
+	for rec := range results {
+		pending[rec.row] = rec
+		for rec, ok := pending[next]; ok; rec, ok = pending[next] {
+			delete(pending, next)
+			next++
+			<-inflight
+			if err == nil {
+				if err = report.add(rec, opts); err != nil {
+					close(stop)
+				}
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if readErr == errStopBatch {
+		return nil
+	}
+	return readErr
+}
+
+var errStopBatch = errors.New("batch stopped")
+
+func (v *Validator) validateRecord(rec *batchRecord, rules map[string]string) {
+	rec.errs, rec.err = Errors(nil).add(v.ValidateRules(rec.data, rules))
+}
+
+// add adds a validated record to the report.
+func (report *BatchReport) add(rec *batchRecord, opts BatchOptions) error {
+	if rec.err != nil {
+		return errors.New("row " + strconv.Itoa(rec.row) + ": " + rec.err.Error())
+	}
+	report.Records++
+	if len(rec.errs) == 0 {
+		report.Valid++
+		return nil
+	}
+	report.Invalid++
+	for _, e := range rec.errs {
+		kind := e.Rule
+		if kind == "" {
+			kind = MsgInvalid
+		}
+		report.Columns[e.Field]++
+		report.Kinds[kind]++
+		if len(report.Samples[kind]) < opts.Samples {
+			report.Samples[kind] = append(report.Samples[kind], BatchSample{Row: rec.row, Error: e})
+		}
+	}
+	row := BatchRow{Row: rec.row, Errors: rec.errs}
+	if opts.Handler != nil {
+		opts.Handler(row)
+	} else {
+		report.Rows = append(report.Rows, row)
+	}
+	return nil
+}
+
+// readCSV calls fn with the rows of r keyed by the header row.
+func readCSV(r io.Reader, fn func(map[string]string) error) error {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	header = append([]string(nil), header...)
+	// Spreadsheet exports may start with a byte order mark.
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		data := make(map[string]string, len(header))
+		for i, key := range header {
+			data[key] = row[i]
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+}
+
+// readJSON calls fn with the JSON objects of r: one after the other or in
+// top-level arrays. The objects are decoded one at a time.
+func readJSON(r io.Reader, fn func(map[string]string) error) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	row := 0
+	rowError := func(err error) error {
+		return errors.New("row " + strconv.Itoa(row) + ": " + err.Error())
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('['):
+			for dec.More() {
+				row++
+				var obj map[string]interface{}
+				if err := dec.Decode(&obj); err != nil {
+					return rowError(err)
+				}
+				if obj == nil {
+					return rowError(errors.New("not a JSON object"))
+				}
+				if err := fn(jsonFields(obj)); err != nil {
+					return err
+				}
+			}
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+		case json.Delim('{'):
+			// The object is opened, decode its members.
+			row++
+			obj, err := jsonObject(dec)
+			if err != nil {
+				return rowError(err)
+			}
+			if err := fn(jsonFields(obj)); err != nil {
+				return err
+			}
+		default:
+			row++
+			return rowError(errors.New("not a JSON object"))
+		}
+	}
+}
+
+// jsonObject decodes the members of an object whose '{' has been read.
+func jsonObject(dec *json.Decoder) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var val interface{}
+		if err := dec.Decode(&val); err != nil {
+			return nil, err
+		}
+		obj[key] = val
+	}
+	_, err := dec.Token()
+	return obj, err
+}
+
+// jsonFields converts the members of a JSON object into field values:
+// numbers as written, objects and arrays as JSON. Null members are missing.
+func jsonFields(obj map[string]interface{}) map[string]string {
+	data := make(map[string]string, len(obj))
+	for key, val := range obj {
+		switch v := val.(type) {
+		case nil:
+		case string:
+			data[key] = v
+		case json.Number:
+			data[key] = v.String()
+		case bool:
+			data[key] = strconv.FormatBool(v)
+		default:
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			enc.Encode(v)
+			data[key] = string(bytes.TrimSpace(buf.Bytes()))
+		}
+	}
+	return data
+}
@@ -0,0 +1,89 @@
+package vvalidator
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var batchRules = map[string]string{
+	"id":    "required|int64|min:1",
+	"email": "required|email",
+	"age":   "int|min:0|max:150",
+}
+
+func TestValidateBatch(t *testing.T) {
+	csv := "\ufeffid,email,age\n1,ann@example.com,30\n0,bob,200\n3,,\n"
+	report, err := ValidateBatch(strings.NewReader(csv), batchRules, BatchOptions{Format: BatchCSV})
+	equal(t, nil, err)
+	equal(t, 3, report.Records)
+	equal(t, 1, report.Valid)
+	equal(t, 2, report.Invalid)
+	equal(t, 2, len(report.Rows))
+	equal(t, 2, report.Rows[0].Row)
+	equal(t, "age is too big (maximum is 150); email must be a valid email; id is too small (minimum is 1)", report.Rows[0].Errors.Error())
+	equal(t, 3, report.Rows[1].Row)
+	equal(t, "email can't be empty", report.Rows[1].Errors.Error())
+	equal(t, map[string]int{"age": 1, "email": 2, "id": 1}, report.Columns)
+	equal(t, map[string]int{"max": 1, "email": 1, "min": 1, "empty": 1}, report.Kinds)
+	equal(t, []BatchSample{{Row: 3, Error: report.Rows[1].Errors[0]}}, report.Samples["empty"])
+
+	ndjson := `{"id": 1, "email": "ann@example.com", "age": 30}` + "\n\n" +
+		`[{"id": 2, "email": null}, {"id": "x", "email": "cy@example.com", "tags": ["a"]}]`
+	report, err = ValidateBatch(strings.NewReader(ndjson), batchRules, BatchOptions{Format: BatchJSON, Samples: -1})
+	equal(t, nil, err)
+	equal(t, 3, report.Records)
+	equal(t, 2, report.Invalid)
+	equal(t, 2, report.Rows[0].Row)
+	equal(t, "email is required", report.Rows[0].Errors.Error())
+	equal(t, 3, report.Rows[1].Row)
+	equal(t, map[string][]BatchSample{}, report.Samples)
+
+	// Input errors stop the batch, the report covers the rows before them.
+	report, err = ValidateBatch(strings.NewReader(`{"id": 1, "email": "a@example.com"} 3`), batchRules, BatchOptions{Format: BatchJSON})
+	equal(t, "row 2: not a JSON object", err.Error())
+	equal(t, 1, report.Valid)
+	_, err = ValidateBatch(strings.NewReader("id,email\n1\n"), batchRules, BatchOptions{Format: BatchCSV})
+	equal(t, "record on line 2: wrong number of fields", err.Error())
+	_, err = ValidateBatch(strings.NewReader(""), batchRules, BatchOptions{Format: "xml"})
+	equal(t, "unsupported batch format xml", err.Error())
+	_, err = ValidateBatch(strings.NewReader(""), map[string]string{"id": "min:x"}, BatchOptions{Format: BatchCSV})
+	equal(t, "invalid bound x in rule min:x", err.Error())
+}
+
+func TestValidateBatchWorkers(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("id,email,age\n")
+	for i := 1; i <= 2000; i++ {
+		email := "user" + strconv.Itoa(i) + "@example.com"
+		if i%7 == 0 {
+			email = "invalid"
+		}
+		b.WriteString(strconv.Itoa(i%13) + "," + email + "," + strconv.Itoa(i%200) + "\n")
+	}
+
+	want, err := ValidateBatch(strings.NewReader(b.String()), batchRules, BatchOptions{Format: BatchCSV})
+	equal(t, nil, err)
+	for _, workers := range []int{2, 8} {
+		var rows []BatchRow
+		got, err := ValidateBatch(strings.NewReader(b.String()), batchRules, BatchOptions{
+			Format:  BatchCSV,
+			Workers: workers,
+			Handler: func(row BatchRow) { rows = append(rows, row) },
+		})
+		equal(t, nil, err)
+		equal(t, want.Rows, rows)
+		equal(t, []BatchRow(nil), got.Rows)
+		got.Rows = want.Rows
+		equal(t, want, got)
+	}
+	equal(t, 2000, want.Records)
+	equal(t, DefaultBatchSamples, len(want.Samples["email"]))
+	equal(t, 7, want.Samples["email"][0].Row)
+
+	// Validation failures other than errors stop the workers.
+	csv := "id,code\n1,\n2,\n3,abc\n4,abc\n"
+	report, err := ValidateBatch(strings.NewReader(csv), map[string]string{"id": "int", "code": "custom"}, BatchOptions{Format: BatchCSV, Workers: 4})
+	equal(t, "row 3: unknown rule custom", err.Error())
+	equal(t, 2, report.Records)
+}
@@ -0,0 +1,44 @@
+package vvalidator
+
+import "sync"
+
+// maxCacheSize limits the entries of the rule and expression caches, so that
+// rules built from input cannot grow them without bound.
+const maxCacheSize = 1024
+
+// cache is a concurrency safe map of at most size entries, an arbitrary
+// entry is evicted to make room for a new one.
+type cache struct {
+	mu      sync.RWMutex
+	size    int
+	entries map[string]interface{}
+}
+
+func newCache(size int) *cache {
+	return &cache{size: size, entries: make(map[string]interface{})}
+}
+
+func (c *cache) load(key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, ok := c.entries[key]
+	return value, ok
+}
+
+func (c *cache) store(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = value
+}
+
+func (c *cache) len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
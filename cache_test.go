@@ -0,0 +1,30 @@
+package vvalidator
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := newCache(2)
+	c.store("a", 1)
+	c.store("a", 2)
+	value, ok := c.load("a")
+	equal(t, true, ok)
+	equal(t, 2, value)
+	c.store("b", 3)
+	c.store("c", 4)
+	equal(t, 2, c.len())
+	value, ok = c.load("c")
+	equal(t, true, ok)
+	equal(t, 4, value)
+	_, ok = c.load("d")
+	equal(t, false, ok)
+
+	for i := 0; i < 2*maxCacheSize; i++ {
+		_, _ = parseRule("max:" + strconv.Itoa(i))
+		_ = ValidateExpr(map[string]string{}, "a", "a == "+strconv.Itoa(i))
+	}
+	equal(t, true, ruleCache.len() <= maxCacheSize)
+	equal(t, true, exprCache.len() <= maxCacheSize)
+}
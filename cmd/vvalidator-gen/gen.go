@@ -0,0 +1,558 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// basicKinds maps the basic Go types onto the value type of their rules,
+// as the rule engine does for struct fields.
+var basicKinds = map[string]string{
+	"int":     "int",
+	"int8":    "int",
+	"int16":   "int",
+	"int32":   "int",
+	"rune":    "int",
+	"uint8":   "int",
+	"byte":    "int",
+	"uint16":  "int",
+	"int64":   "int64",
+	"uint":    "int64",
+	"uint32":  "int64",
+	"uint64":  "int64",
+	"float32": "float",
+	"float64": "float",
+	"string":  "string",
+	"bool":    "string",
+}
+
+type structType struct {
+	name   string
+	fields []*field
+}
+
+type field struct {
+	name  string // Go field name
+	key   string
+	basic string // basic Go type, after resolving local named types
+	ptrs  int
+	named bool // the field type is a local named type
+	rs    *ruleSet
+	// prefix names the package variables of the field.
+	prefix string
+}
+
+// generator writes the validation methods of a package's structs.
+type generator struct {
+	buf     bytes.Buffer
+	vars    bytes.Buffer
+	imports map[string]bool
+}
+
+// generate returns the source of the Validate methods of the struct types
+// of files, only the types listed if types isn't empty.
+func generate(pkg string, files []*ast.File, types []string) ([]byte, error) {
+	structs, err := collect(files, types)
+	if err != nil {
+		return nil, err
+	}
+	if len(structs) == 0 {
+		return nil, errors.New("no struct with valid tags found")
+	}
+
+	g := &generator{imports: make(map[string]bool)}
+	for _, st := range structs {
+		g.structType(st)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by vvalidator-gen. DO NOT EDIT.\n\n")
+	out.WriteString("package " + pkg + "\n\n")
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	out.WriteString("import (\n")
+	for _, path := range imports {
+		out.WriteString(strconv.Quote(path) + "\n")
+	}
+	out.WriteString("\n\"github.com/syyongx/vvalidator\"\n)\n\n")
+	if g.vars.Len() > 0 {
+		out.WriteString("var (\n")
+		out.Write(g.vars.Bytes())
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, errors.New("format generated code: " + err.Error())
+	}
+	return src, nil
+}
+
+// collect returns the struct types of files that have valid tags, in source order.
+func collect(files []*ast.File, types []string) ([]*structType, error) {
+	named := make(map[string]ast.Expr)
+	var specs []*ast.TypeSpec
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				named[ts.Name.Name] = ts.Type
+				specs = append(specs, ts)
+			}
+		}
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range types {
+		wanted[name] = true
+	}
+	var structs []*structType
+	for _, ts := range specs {
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok || len(types) > 0 && !wanted[ts.Name.Name] {
+			continue
+		}
+		delete(wanted, ts.Name.Name)
+		s := &structType{name: ts.Name.Name}
+		for _, f := range st.Fields.List {
+			if f.Tag == nil {
+				continue
+			}
+			tagValue, _ := strconv.Unquote(f.Tag.Value)
+			tag := reflect.StructTag(tagValue)
+			rule, ok := tag.Lookup("valid")
+			if !ok {
+				continue
+			}
+			if len(f.Names) == 0 {
+				return nil, errors.New(s.name + ": embedded field with valid tag is not supported")
+			}
+			for _, name := range f.Names {
+				if !name.IsExported() {
+					continue
+				}
+				fd, err := newField(s.name, name.Name, tag, rule, f.Type, named)
+				if err != nil {
+					return nil, err
+				}
+				s.fields = append(s.fields, fd)
+			}
+		}
+		if len(s.fields) > 0 || len(types) > 0 {
+			structs = append(structs, s)
+		}
+	}
+	for _, name := range types {
+		if wanted[name] {
+			return nil, errors.New("struct type " + name + " not found")
+		}
+	}
+	return structs, nil
+}
+
+func newField(typeName, name string, tag reflect.StructTag, rule string, typ ast.Expr, named map[string]ast.Expr) (*field, error) {
+	f := &field{name: name, key: name, prefix: strings.ToLower(typeName[:1]) + typeName[1:] + name}
+	if json := strings.Split(tag.Get("json"), ",")[0]; json != "" && json != "-" {
+		f.key = json
+	}
+	for {
+		if star, ok := typ.(*ast.StarExpr); ok {
+			f.ptrs++
+			typ = star.X
+			continue
+		}
+		// Local named types resolve to their underlying type, e.g. type Status string.
+		if ident, ok := typ.(*ast.Ident); ok {
+			if _, ok := basicKinds[ident.Name]; !ok {
+				if under, ok := named[ident.Name]; ok && under != typ {
+					f.named = true
+					typ = under
+					continue
+				}
+			}
+		}
+		break
+	}
+	ident, ok := typ.(*ast.Ident)
+	if !ok || basicKinds[ident.Name] == "" {
+		return nil, errors.New(typeName + "." + name + " has unsupported type")
+	}
+	f.basic = ident.Name
+
+	rs, err := parseRule(rule)
+	if err != nil {
+		return nil, errors.New(typeName + "." + name + ": " + err.Error())
+	}
+	if rs.Pattern != "" {
+		if _, err := regexp.Compile(rs.Pattern); err != nil {
+			return nil, errors.New(typeName + "." + name + ": " + err.Error())
+		}
+	}
+	if rs.Kind == "" {
+		rs.Kind = basicKinds[f.basic]
+	}
+	if rs.In != nil && rs.Kind != "string" {
+		numeric := false
+		for _, in := range rs.In {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(in), 64); err == nil {
+				numeric = true
+			}
+		}
+		if !numeric {
+			return nil, errors.New(typeName + "." + name + ": in has no " + rs.Kind + " value")
+		}
+	}
+	f.rs = rs
+	return f, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) structType(st *structType) {
+	hasExpr := false
+	g.printf("// Validate validates the fields of %s with their valid tags like\n", st.name)
+	g.printf("// vvalidator.ValidateStruct, using the default validator.\n")
+	g.printf("func (s *%s) Validate() error {\n", st.name)
+	g.printf("return s.ValidateWith(vvalidator.Default())\n}\n\n")
+
+	g.printf("// ValidateWith validates the fields of %s with the messages of v.\n", st.name)
+	g.printf("func (s *%s) ValidateWith(v *vvalidator.Validator) error {\n", st.name)
+	g.printf("var errs vvalidator.Errors\n")
+	g.printf("for _, check := range []func(*vvalidator.Validator) error{\n")
+	for _, f := range st.fields {
+		g.printf("s.validate%s,\n", f.name)
+		hasExpr = hasExpr || f.rs.Expr != nil
+	}
+	g.printf("} {\n")
+	g.printf("switch err := check(v).(type) {\ncase nil:\ncase vvalidator.Error:\nerrs = append(errs, err)\ndefault:\nreturn err\n}\n}\n")
+	g.printf("if len(errs) > 0 {\nreturn errs\n}\nreturn nil\n}\n\n")
+
+	for _, f := range st.fields {
+		g.field(st, f)
+	}
+	if hasExpr {
+		g.fields(st)
+	}
+}
+
+// fields writes the method returning the field values for expressions.
+func (g *generator) fields(st *structType) {
+	g.imports["strings"] = true
+	g.printf("// validatorFields returns the values of the fields of %s by key, for expressions.\n", st.name)
+	g.printf("func (s *%s) validatorFields(v *vvalidator.Validator) map[string]string {\n", st.name)
+	g.printf("fields := make(map[string]string, %d)\n", len(st.fields))
+	for _, f := range st.fields {
+		x := "s." + f.name
+		if f.ptrs > 0 {
+			g.printf("if %s {\n", nilCheck(x, f.ptrs, "!=", " && "))
+			x = strings.Repeat("*", f.ptrs) + x
+		}
+		g.printf("fields[%q] = %s\n", f.key, g.value(f, x))
+		if f.ptrs > 0 {
+			g.printf("}\n")
+		}
+	}
+	g.printf("if v.Trim {\nfor key, val := range fields {\nfields[key] = strings.TrimSpace(val)\n}\n}\n")
+	g.printf("return fields\n}\n\n")
+}
+
+// nilCheck returns the comparisons with nil of the ptrs levels of a pointer
+// field joined by sep, e.g. "s.F == nil || *s.F == nil".
+func nilCheck(x string, ptrs int, op, sep string) string {
+	conds := make([]string, ptrs)
+	for i := range conds {
+		conds[i] = strings.Repeat("*", i) + x + " " + op + " nil"
+	}
+	return strings.Join(conds, sep)
+}
+
+// conv returns x converted to the basic type typ if needed.
+func conv(f *field, typ, x string) string {
+	if f.basic == typ && !f.named {
+		return x
+	}
+	return typ + "(" + x + ")"
+}
+
+// value returns the expression of the string value of field f as the rule
+// engine formats it, x is the field value.
+func (g *generator) value(f *field, x string) string {
+	switch f.basic {
+	case "string":
+		return conv(f, "string", x)
+	case "bool":
+		g.imports["strconv"] = true
+		return "strconv.FormatBool(" + conv(f, "bool", x) + ")"
+	case "float32":
+		g.imports["strconv"] = true
+		return "strconv.FormatFloat(float64(" + x + "), 'f', -1, 32)"
+	case "float64":
+		g.imports["strconv"] = true
+		return "strconv.FormatFloat(" + conv(f, "float64", x) + ", 'f', -1, 64)"
+	case "uint", "uint8", "byte", "uint16", "uint32", "uint64":
+		g.imports["strconv"] = true
+		return "strconv.FormatUint(" + conv(f, "uint64", x) + ", 10)"
+	}
+	g.imports["strconv"] = true
+	return "strconv.FormatInt(" + conv(f, "int64", x) + ", 10)"
+}
+
+// fail returns the statement returning the validation error of id.
+func fail(id string, f *field, params ...string) string {
+	s := "return v.FieldError(vvalidator." + id + ", " + strconv.Quote(f.key) + ", val"
+	for _, p := range params {
+		s += ", " + strconv.Quote(p)
+	}
+	return s + ")"
+}
+
+// field writes the method validating a field, it returns the first error
+// of the field as the rule engine does.
+func (g *generator) field(st *structType, f *field) {
+	rs := f.rs
+	if rule := rs.base + exprSuffix(rs); rule != "" {
+		g.printf("// validate%s validates %s: %s\n", f.name, f.key, rule)
+	} else {
+		g.printf("// validate%s validates %s, it has no rules.\n", f.name, f.key)
+	}
+	g.printf("func (s *%s) validate%s(v *vvalidator.Validator) error {\n", st.name, f.name)
+	x := "s." + f.name
+	if f.ptrs > 0 {
+		g.printf("if %s {\n", nilCheck(x, f.ptrs, "==", " || "))
+		if rs.Required {
+			g.printf("return v.FieldError(vvalidator.MsgRequired, %q, \"\")\n", f.key)
+		} else {
+			g.printf("return nil\n")
+		}
+		g.printf("}\n")
+		x = strings.Repeat("*", f.ptrs) + x
+	}
+
+	native := basicKinds[f.basic]
+	if native == "int64" {
+		native = "int"
+	}
+	kind := rs.Kind
+	if kind == "int64" {
+		kind = "int"
+	}
+	switch {
+	case kind == "string" && native == "string":
+		g.stringChecks(f, x)
+	case kind == "int" && native == "int":
+		g.intChecks(f, x)
+	case kind == "float" && native == "float":
+		g.floatChecks(f, x)
+	default:
+		// Other combinations of value types are left to the rule engine.
+		g.printf("val := %s\n", g.value(f, x))
+		if f.basic == "string" {
+			g.imports["strings"] = true
+			g.printf("if v.Trim {\nval = strings.TrimSpace(val)\n}\n")
+		}
+		g.printf("if err := v.ValidateRule(val, %q, %q); err != nil {\nreturn err\n}\n", f.key, rs.base)
+		g.exprCheck(f)
+	}
+	g.printf("return nil\n}\n\n")
+}
+
+func exprSuffix(rs *ruleSet) string {
+	if rs.Expr == nil {
+		return ""
+	}
+	if rs.base == "" {
+		return "expr:" + rs.Expr.String()
+	}
+	return "|expr:" + rs.Expr.String()
+}
+
+func (g *generator) stringChecks(f *field, x string) {
+	rs := f.rs
+	if !hasChecks(rs) && !(f.basic == "string" && rs.Required) {
+		return
+	}
+	g.printf("val := %s\n", g.value(f, x))
+	if f.basic == "string" {
+		g.imports["strings"] = true
+		g.printf("if v.Trim {\nval = strings.TrimSpace(val)\n}\n")
+		g.printf("if val == \"\" {\n")
+		if rs.Required {
+			g.printf("%s\n", fail("MsgEmpty", f))
+		} else {
+			g.printf("return nil\n")
+		}
+		g.printf("}\n")
+	}
+
+	min, hasMin := ruleInt(rs.Min, true)
+	max, hasMax := ruleInt(rs.Max, false)
+	if hasMin || hasMax {
+		g.imports["unicode/utf8"] = true
+		params := rs.bounds()
+		g.printf("length := utf8.RuneCountInString(val)\n")
+		if hasMin {
+			g.printf("if length < %d {\n%s\n}\n", min, fail("MsgMinLength", f, params...))
+		}
+		if hasMax {
+			g.printf("if length > %d {\n%s\n}\n", max, fail("MsgMaxLength", f, params...))
+		}
+	}
+	if rs.In != nil {
+		conds := make([]string, len(rs.In))
+		for i, in := range rs.In {
+			conds[i] = "val != " + strconv.Quote(strings.TrimSpace(in))
+		}
+		g.printf("if %s {\n%s\n}\n", strings.Join(conds, " && "), fail("MsgInvalid", f))
+	}
+	g.commonChecks(f)
+}
+
+func (g *generator) intChecks(f *field, x string) {
+	rs := f.rs
+	overflow := f.basic == "uint" || f.basic == "uint64"
+	if !overflow && !hasChecks(rs) {
+		return
+	}
+	g.printf("val := %s\n", g.value(f, x))
+	if overflow {
+		g.imports["math"] = true
+		id := "MsgInt"
+		if rs.Kind == "int64" {
+			id = "MsgInt64"
+		}
+		g.printf("if %s > math.MaxInt64 {\n%s\n}\n", conv(f, "uint64", x), fail(id, f))
+	}
+
+	min, hasMin := ruleInt(rs.Min, true)
+	max, hasMax := ruleInt(rs.Max, false)
+	params := rs.bounds()
+	if hasMin {
+		g.printf("if %s < %d {\n%s\n}\n", conv(f, "int64", x), min, fail("MsgMin", f, params...))
+	}
+	if hasMax {
+		g.printf("if %s > %d {\n%s\n}\n", conv(f, "int64", x), max, fail("MsgMax", f, params...))
+	}
+	if rs.In != nil {
+		conds := []string{}
+		for _, in := range rs.In {
+			if n, err := strconv.ParseInt(strings.TrimSpace(in), 10, 64); err == nil {
+				conds = append(conds, conv(f, "int64", x)+" != "+strconv.FormatInt(n, 10))
+			}
+		}
+		g.printf("if %s {\n%s\n}\n", strings.Join(conds, " && "), fail("MsgInvalid", f))
+	}
+	g.commonChecks(f)
+}
+
+func (g *generator) floatChecks(f *field, x string) {
+	rs := f.rs
+	g.imports["math"] = true
+	g.printf("val := %s\n", g.value(f, x))
+	num := conv(f, "float64", x)
+	if f.basic == "float32" {
+		// The rule engine compares the shortest decimal form of a float32.
+		g.printf("num, _ := strconv.ParseFloat(val, 64)\n")
+		num = "num"
+	}
+	g.printf("if math.IsNaN(%s) || math.IsInf(%s, 0) {\n%s\n}\n", num, num, fail("MsgFloat", f))
+
+	min, hasMin := ruleFloat(rs.Min)
+	max, hasMax := ruleFloat(rs.Max)
+	params := rs.bounds()
+	if hasMin {
+		g.printf("if %s < %s {\n%s\n}\n", num, floatLiteral(min), fail("MsgMin", f, params...))
+	}
+	if hasMax {
+		g.printf("if %s > %s {\n%s\n}\n", num, floatLiteral(max), fail("MsgMax", f, params...))
+	}
+	if rs.In != nil {
+		conds := []string{}
+		for _, in := range rs.In {
+			if n, err := strconv.ParseFloat(strings.TrimSpace(in), 64); err == nil {
+				conds = append(conds, num+" != "+floatLiteral(n))
+			}
+		}
+		g.printf("if %s {\n%s\n}\n", strings.Join(conds, " && "), fail("MsgInvalid", f))
+	}
+	g.commonChecks(f)
+}
+
+// hasChecks reports whether a rule set checks non-empty values.
+func hasChecks(rs *ruleSet) bool {
+	return rs.Min != "" || rs.Max != "" || rs.In != nil || rs.Pattern != "" || rs.Checks != nil || rs.Expr != nil
+}
+
+func floatLiteral(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// commonChecks writes the pattern, named rule and expression checks of f.
+func (g *generator) commonChecks(f *field) {
+	rs := f.rs
+	if rs.Pattern != "" {
+		g.imports["regexp"] = true
+		name := f.prefix + "Pattern"
+		fmt.Fprintf(&g.vars, "%s = regexp.MustCompile(%s)\n", name, quote(rs.Pattern))
+		g.printf("if !%s.MatchString(val) {\n%s\n}\n", name, fail("MsgPattern", f))
+	}
+	for _, c := range rs.Checks {
+		p, ok := builtinChecks[c.Name]
+		if !ok {
+			// Custom rules are registered at run time.
+			rule := c.Name
+			if c.Param != "" {
+				rule += ":" + c.Param
+			}
+			g.printf("if err := v.ValidateRule(val, %q, %q); err != nil {\nreturn err\n}\n", f.key, rule)
+			continue
+		}
+		call := "vvalidator." + p.Func + "(val)"
+		if p.Param {
+			call = "vvalidator." + p.Func + "(val, " + strconv.Quote(c.Param) + ")"
+		}
+		g.printf("if !%s {\n%s\n}\n", call, fail("MsgRule", f, "rule", c.Name))
+	}
+	g.exprCheck(f)
+}
+
+func (g *generator) exprCheck(f *field) {
+	if f.rs.Expr == nil {
+		return
+	}
+	name := f.prefix + "Expr"
+	fmt.Fprintf(&g.vars, "%s = vvalidator.MustCompileExpr(%s)\n", name, quote(f.rs.Expr.String()))
+	g.printf("if ok, err := %s.Match(s.validatorFields(v)); err != nil || !ok {\n%s\n}\n",
+		name, fail("MsgInvalid", f))
+}
+
+// quote returns s as a raw string literal if possible.
+func quote(s string) string {
+	if !strings.Contains(s, "`") && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// TestGolden checks that the generated code of the example package is up to
+// date, its tests compare it with the rule engine.
+func TestGolden(t *testing.T) {
+	src, err := generateFiles([]string{"internal/example/example.go"}, []string{"Order", "User", "Metrics"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := "internal/example/example_vvalidator.go"
+	if *update {
+		if err := os.WriteFile(golden, src, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(want, src) {
+		t.Errorf("%s is out of date, run go test -update", golden)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, tt := range []struct {
+		src, err string
+	}{
+		{"type T struct { A []string `valid:\"required\"` }", "T.A has unsupported type"},
+		{"type T struct { A int `valid:\"min:x\"` }", "T.A: invalid bound x in rule min:x"},
+		{"type T struct { A string `valid:\"pattern:(\"` }", "T.A: error parsing regexp: missing closing ): `(`"},
+		{"type T struct { A int `valid:\"in:a,b\"` }", "T.A: in has no int value"},
+		{"type T struct { A int }", "no struct with valid tags found"},
+	} {
+		dir := t.TempDir()
+		filename := dir + "/t.go"
+		if err := os.WriteFile(filename, []byte("package t\n\n"+tt.src+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := generateFiles([]string{filename}, nil)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("%s: got error %v, want %s", tt.src, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Package example holds structs whose generated validation methods are
+// tested against the rule engine.
+package example
+
+//go:generate go run github.com/syyongx/vvalidator/cmd/vvalidator-gen -type Order,User,Metrics
+
+// Status is a user status.
+type Status string
+
+// Order is an order with numeric and expression rules.
+type Order struct {
+	ID       uint64  `json:"id" valid:"required|min:1"`
+	Price    float64 `json:"price" valid:"required|min:0|max:10000"`
+	Discount float64 `json:"discount" valid:"expr:discount <= price * 0.5"`
+	Currency *string `json:"currency" valid:"required|in:USD,EUR"`
+	Quantity *int    `json:"quantity" valid:"int|min:1|max:100|in:1,5,10,100"`
+	Weight   float32 `json:"weight" valid:"max:99.9"`
+	Code     string  `json:"code" valid:"int64|min:100"`
+	Note     string
+}
+
+// User is a user with string rules.
+type User struct {
+	Email    string `json:"email" valid:"required|email|max:50"`
+	Nickname string `json:"nickname" valid:"min:2|max:10|pattern:^[a-z]+$"`
+	Status   Status `json:"status" valid:"in:active,disabled"`
+	Avatar   string `json:"avatar" valid:"url|hash:md5"`
+	Country  string `json:"country" valid:"country"`
+	Admin    bool   `json:"admin" valid:"in:true"`
+	Age      int8   `json:"age" valid:""`
+}
+
+// Metrics has the other numeric types.
+type Metrics struct {
+	Count  uint     `json:"count" valid:"int|max:1000"`
+	Delta  int32    `json:"delta" valid:"min:-100|max:100"`
+	Ratio  *float32 `json:"ratio" valid:"min:0|max:1"`
+	Level  int      `json:"level" valid:"string|max:1"`
+	Offset int64    `json:"offset" valid:"min:-1|max:9007199254740993"`
+}
@@ -0,0 +1,53 @@
+package example
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/syyongx/vvalidator"
+)
+
+type validated interface {
+	ValidateWith(v *vvalidator.Validator) error
+}
+
+// TestGenerated checks that the generated methods return the same errors
+// as the rule engine.
+func TestGenerated(t *testing.T) {
+	usd, cny, blank := "USD", "CNY", " "
+	zero, one, five, seven := 0, 1, 5, 7
+	half, two := float32(0.5), float32(2)
+	values := []validated{
+		&Order{ID: 1, Price: 100, Discount: 20, Currency: &usd, Quantity: &five, Weight: 1.5, Code: "123"},
+		&Order{},
+		&Order{ID: 1 << 63, Price: -1, Discount: 60, Currency: &cny, Quantity: &zero, Weight: 99.9, Code: "99"},
+		&Order{Price: 20000, Discount: 2, Currency: &blank, Quantity: &seven, Weight: 100, Code: "x"},
+		&Order{ID: 2, Price: 1, Currency: &usd, Quantity: &one, Code: " 100 "},
+		&User{Email: "a@b.com", Nickname: "abc", Status: "active", Admin: true},
+		&User{},
+		&User{Email: " a", Nickname: "a", Status: "x", Avatar: "abc", Country: "FR"},
+		&User{Email: "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz@b.com", Nickname: "ABC", Avatar: "0cc175b9c0f1b6a831c399e269772661"},
+		&User{Email: "a@b.com", Nickname: "abcdefghijk", Avatar: "http://x.com"},
+		&Metrics{Count: 1000, Delta: -100, Ratio: &half, Level: 1},
+		&Metrics{Count: 1001, Delta: 101, Ratio: &two, Level: 10, Offset: 9007199254740994},
+		&Metrics{Delta: -101, Offset: -2},
+		&Metrics{Offset: 9007199254740993},
+	}
+
+	v := vvalidator.New()
+	trim := vvalidator.New()
+	trim.Trim = true
+	trim.RegisterRule("country", func(value, param string) bool { return len(value) == 2 })
+	for _, val := range []*vvalidator.Validator{v, trim} {
+		for i, s := range values {
+			want := val.ValidateStruct(s)
+			got := s.ValidateWith(val)
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("%d (trim %v): got %v, want %v", i, val.Trim, got, want)
+			}
+		}
+	}
+	if err := (&Order{}).Validate(); err == nil || err.Error() != vvalidator.ValidateStruct(&Order{}).Error() {
+		t.Errorf("Validate: got %v", err)
+	}
+}
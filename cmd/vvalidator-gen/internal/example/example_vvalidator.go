@@ -0,0 +1,401 @@
+// Code generated by vvalidator-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/syyongx/vvalidator"
+)
+
+var (
+	orderDiscountExpr   = vvalidator.MustCompileExpr(`discount <= price * 0.5`)
+	userNicknamePattern = regexp.MustCompile(`^[a-z]+$`)
+)
+
+// Validate validates the fields of Order with their valid tags like
+// vvalidator.ValidateStruct, using the default validator.
+func (s *Order) Validate() error {
+	return s.ValidateWith(vvalidator.Default())
+}
+
+// ValidateWith validates the fields of Order with the messages of v.
+func (s *Order) ValidateWith(v *vvalidator.Validator) error {
+	var errs vvalidator.Errors
+	for _, check := range []func(*vvalidator.Validator) error{
+		s.validateID,
+		s.validatePrice,
+		s.validateDiscount,
+		s.validateCurrency,
+		s.validateQuantity,
+		s.validateWeight,
+		s.validateCode,
+	} {
+		switch err := check(v).(type) {
+		case nil:
+		case vvalidator.Error:
+			errs = append(errs, err)
+		default:
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateID validates id: required|min:1
+func (s *Order) validateID(v *vvalidator.Validator) error {
+	val := strconv.FormatUint(s.ID, 10)
+	if s.ID > math.MaxInt64 {
+		return v.FieldError(vvalidator.MsgInt64, "id", val)
+	}
+	if int64(s.ID) < 1 {
+		return v.FieldError(vvalidator.MsgMin, "id", val, "min", "1")
+	}
+	return nil
+}
+
+// validatePrice validates price: required|min:0|max:10000
+func (s *Order) validatePrice(v *vvalidator.Validator) error {
+	val := strconv.FormatFloat(s.Price, 'f', -1, 64)
+	if math.IsNaN(s.Price) || math.IsInf(s.Price, 0) {
+		return v.FieldError(vvalidator.MsgFloat, "price", val)
+	}
+	if s.Price < 0.0 {
+		return v.FieldError(vvalidator.MsgMin, "price", val, "min", "0", "max", "10000")
+	}
+	if s.Price > 10000.0 {
+		return v.FieldError(vvalidator.MsgMax, "price", val, "min", "0", "max", "10000")
+	}
+	return nil
+}
+
+// validateDiscount validates discount: expr:discount <= price * 0.5
+func (s *Order) validateDiscount(v *vvalidator.Validator) error {
+	val := strconv.FormatFloat(s.Discount, 'f', -1, 64)
+	if math.IsNaN(s.Discount) || math.IsInf(s.Discount, 0) {
+		return v.FieldError(vvalidator.MsgFloat, "discount", val)
+	}
+	if ok, err := orderDiscountExpr.Match(s.validatorFields(v)); err != nil || !ok {
+		return v.FieldError(vvalidator.MsgInvalid, "discount", val)
+	}
+	return nil
+}
+
+// validateCurrency validates currency: required|in:USD,EUR
+func (s *Order) validateCurrency(v *vvalidator.Validator) error {
+	if s.Currency == nil {
+		return v.FieldError(vvalidator.MsgRequired, "currency", "")
+	}
+	val := *s.Currency
+	if v.Trim {
+		val = strings.TrimSpace(val)
+	}
+	if val == "" {
+		return v.FieldError(vvalidator.MsgEmpty, "currency", val)
+	}
+	if val != "USD" && val != "EUR" {
+		return v.FieldError(vvalidator.MsgInvalid, "currency", val)
+	}
+	return nil
+}
+
+// validateQuantity validates quantity: int|min:1|max:100|in:1,5,10,100
+func (s *Order) validateQuantity(v *vvalidator.Validator) error {
+	if s.Quantity == nil {
+		return nil
+	}
+	val := strconv.FormatInt(int64(*s.Quantity), 10)
+	if int64(*s.Quantity) < 1 {
+		return v.FieldError(vvalidator.MsgMin, "quantity", val, "min", "1", "max", "100")
+	}
+	if int64(*s.Quantity) > 100 {
+		return v.FieldError(vvalidator.MsgMax, "quantity", val, "min", "1", "max", "100")
+	}
+	if int64(*s.Quantity) != 1 && int64(*s.Quantity) != 5 && int64(*s.Quantity) != 10 && int64(*s.Quantity) != 100 {
+		return v.FieldError(vvalidator.MsgInvalid, "quantity", val)
+	}
+	return nil
+}
+
+// validateWeight validates weight: max:99.9
+func (s *Order) validateWeight(v *vvalidator.Validator) error {
+	val := strconv.FormatFloat(float64(s.Weight), 'f', -1, 32)
+	num, _ := strconv.ParseFloat(val, 64)
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return v.FieldError(vvalidator.MsgFloat, "weight", val)
+	}
+	if num > 99.9 {
+		return v.FieldError(vvalidator.MsgMax, "weight", val, "max", "99.9")
+	}
+	return nil
+}
+
+// validateCode validates code: int64|min:100
+func (s *Order) validateCode(v *vvalidator.Validator) error {
+	val := s.Code
+	if v.Trim {
+		val = strings.TrimSpace(val)
+	}
+	if err := v.ValidateRule(val, "code", "int64|min:100"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validatorFields returns the values of the fields of Order by key, for expressions.
+func (s *Order) validatorFields(v *vvalidator.Validator) map[string]string {
+	fields := make(map[string]string, 7)
+	fields["id"] = strconv.FormatUint(s.ID, 10)
+	fields["price"] = strconv.FormatFloat(s.Price, 'f', -1, 64)
+	fields["discount"] = strconv.FormatFloat(s.Discount, 'f', -1, 64)
+	if s.Currency != nil {
+		fields["currency"] = *s.Currency
+	}
+	if s.Quantity != nil {
+		fields["quantity"] = strconv.FormatInt(int64(*s.Quantity), 10)
+	}
+	fields["weight"] = strconv.FormatFloat(float64(s.Weight), 'f', -1, 32)
+	fields["code"] = s.Code
+	if v.Trim {
+		for key, val := range fields {
+			fields[key] = strings.TrimSpace(val)
+		}
+	}
+	return fields
+}
+
+// Validate validates the fields of User with their valid tags like
+// vvalidator.ValidateStruct, using the default validator.
+func (s *User) Validate() error {
+	return s.ValidateWith(vvalidator.Default())
+}
+
+// ValidateWith validates the fields of User with the messages of v.
+func (s *User) ValidateWith(v *vvalidator.Validator) error {
+	var errs vvalidator.Errors
+	for _, check := range []func(*vvalidator.Validator) error{
+		s.validateEmail,
+		s.validateNickname,
+		s.validateStatus,
+		s.validateAvatar,
+		s.validateCountry,
+		s.validateAdmin,
+		s.validateAge,
+	} {
+		switch err := check(v).(type) {
+		case nil:
+		case vvalidator.Error:
+			errs = append(errs, err)
+		default:
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateEmail validates email: required|email|max:50
+func (s *User) validateEmail(v *vvalidator.Validator) error {
+	val := s.Email
+	if v.Trim {
+		val = strings.TrimSpace(val)
+	}
+	if val == "" {
+		return v.FieldError(vvalidator.MsgEmpty, "email", val)
+	}
+	length := utf8.RuneCountInString(val)
+	if length > 50 {
+		return v.FieldError(vvalidator.MsgMaxLength, "email", val, "max", "50")
+	}
+	if !vvalidator.IsEmail(val) {
+		return v.FieldError(vvalidator.MsgRule, "email", val, "rule", "email")
+	}
+	return nil
+}
+
+// validateNickname validates nickname: min:2|max:10|pattern:^[a-z]+$
+func (s *User) validateNickname(v *vvalidator.Validator) error {
+	val := s.Nickname
+	if v.Trim {
+		val = strings.TrimSpace(val)
+	}
+	if val == "" {
+		return nil
+	}
+	length := utf8.RuneCountInString(val)
+	if length < 2 {
+		return v.FieldError(vvalidator.MsgMinLength, "nickname", val, "min", "2", "max", "10")
+	}
+	if length > 10 {
+		return v.FieldError(vvalidator.MsgMaxLength, "nickname", val, "min", "2", "max", "10")
+	}
+	if !userNicknamePattern.MatchString(val) {
+		return v.FieldError(vvalidator.MsgPattern, "nickname", val)
+	}
+	return nil
+}
+
+// validateStatus validates status: in:active,disabled
+func (s *User) validateStatus(v *vvalidator.Validator) error {
+	val := string(s.Status)
+	if v.Trim {
+		val = strings.TrimSpace(val)
+	}
+	if val == "" {
+		return nil
+	}
+	if val != "active" && val != "disabled" {
+		return v.FieldError(vvalidator.MsgInvalid, "status", val)
+	}
+	return nil
+}
+
+// validateAvatar validates avatar: url|hash:md5
+func (s *User) validateAvatar(v *vvalidator.Validator) error {
+	val := s.Avatar
+	if v.Trim {
+		val = strings.TrimSpace(val)
+	}
+	if val == "" {
+		return nil
+	}
+	if !vvalidator.IsURL(val) {
+		return v.FieldError(vvalidator.MsgRule, "avatar", val, "rule", "url")
+	}
+	if !vvalidator.IsHash(val, "md5") {
+		return v.FieldError(vvalidator.MsgRule, "avatar", val, "rule", "hash")
+	}
+	return nil
+}
+
+// validateCountry validates country: country
+func (s *User) validateCountry(v *vvalidator.Validator) error {
+	val := s.Country
+	if v.Trim {
+		val = strings.TrimSpace(val)
+	}
+	if val == "" {
+		return nil
+	}
+	if err := v.ValidateRule(val, "country", "country"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateAdmin validates admin: in:true
+func (s *User) validateAdmin(v *vvalidator.Validator) error {
+	val := strconv.FormatBool(s.Admin)
+	if val != "true" {
+		return v.FieldError(vvalidator.MsgInvalid, "admin", val)
+	}
+	return nil
+}
+
+// validateAge validates age, it has no rules.
+func (s *User) validateAge(v *vvalidator.Validator) error {
+	return nil
+}
+
+// Validate validates the fields of Metrics with their valid tags like
+// vvalidator.ValidateStruct, using the default validator.
+func (s *Metrics) Validate() error {
+	return s.ValidateWith(vvalidator.Default())
+}
+
+// ValidateWith validates the fields of Metrics with the messages of v.
+func (s *Metrics) ValidateWith(v *vvalidator.Validator) error {
+	var errs vvalidator.Errors
+	for _, check := range []func(*vvalidator.Validator) error{
+		s.validateCount,
+		s.validateDelta,
+		s.validateRatio,
+		s.validateLevel,
+		s.validateOffset,
+	} {
+		switch err := check(v).(type) {
+		case nil:
+		case vvalidator.Error:
+			errs = append(errs, err)
+		default:
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateCount validates count: int|max:1000
+func (s *Metrics) validateCount(v *vvalidator.Validator) error {
+	val := strconv.FormatUint(uint64(s.Count), 10)
+	if uint64(s.Count) > math.MaxInt64 {
+		return v.FieldError(vvalidator.MsgInt, "count", val)
+	}
+	if int64(s.Count) > 1000 {
+		return v.FieldError(vvalidator.MsgMax, "count", val, "max", "1000")
+	}
+	return nil
+}
+
+// validateDelta validates delta: min:-100|max:100
+func (s *Metrics) validateDelta(v *vvalidator.Validator) error {
+	val := strconv.FormatInt(int64(s.Delta), 10)
+	if int64(s.Delta) < -100 {
+		return v.FieldError(vvalidator.MsgMin, "delta", val, "min", "-100", "max", "100")
+	}
+	if int64(s.Delta) > 100 {
+		return v.FieldError(vvalidator.MsgMax, "delta", val, "min", "-100", "max", "100")
+	}
+	return nil
+}
+
+// validateRatio validates ratio: min:0|max:1
+func (s *Metrics) validateRatio(v *vvalidator.Validator) error {
+	if s.Ratio == nil {
+		return nil
+	}
+	val := strconv.FormatFloat(float64(*s.Ratio), 'f', -1, 32)
+	num, _ := strconv.ParseFloat(val, 64)
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return v.FieldError(vvalidator.MsgFloat, "ratio", val)
+	}
+	if num < 0.0 {
+		return v.FieldError(vvalidator.MsgMin, "ratio", val, "min", "0", "max", "1")
+	}
+	if num > 1.0 {
+		return v.FieldError(vvalidator.MsgMax, "ratio", val, "min", "0", "max", "1")
+	}
+	return nil
+}
+
+// validateLevel validates level: string|max:1
+func (s *Metrics) validateLevel(v *vvalidator.Validator) error {
+	val := strconv.FormatInt(int64(s.Level), 10)
+	if err := v.ValidateRule(val, "level", "string|max:1"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateOffset validates offset: min:-1|max:9007199254740993
+func (s *Metrics) validateOffset(v *vvalidator.Validator) error {
+	val := strconv.FormatInt(s.Offset, 10)
+	if s.Offset < -1 {
+		return v.FieldError(vvalidator.MsgMin, "offset", val, "min", "-1", "max", "9007199254740993")
+	}
+	if s.Offset > 9007199254740993 {
+		return v.FieldError(vvalidator.MsgMax, "offset", val, "min", "-1", "max", "9007199254740993")
+	}
+	return nil
+}
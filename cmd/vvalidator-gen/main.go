@@ -0,0 +1,86 @@
+// Command vvalidator-gen generates reflection-free validation methods for
+// structs with `valid` tags. For each struct it writes
+//
+//	func (s *T) Validate() error
+//	func (s *T) ValidateWith(v *vvalidator.Validator) error
+//
+// which call the Is* functions and range checks directly and return the
+// same errors as vvalidator.ValidateStruct. Use it with go generate:
+//
+//	//go:generate vvalidator-gen -type Order,User
+//
+// Usage:
+//
+//	vvalidator-gen [-type T1,T2] [-output file] [file.go ...]
+//
+// The files default to $GOFILE and the output to <file>_vvalidator.go.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names, all structs with valid tags if empty")
+	output := flag.String("output", "", "output file name, default <file>_vvalidator.go")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: vvalidator-gen [-type T1,T2] [-output file] [file.go ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	filenames := flag.Args()
+	if len(filenames) == 0 && os.Getenv("GOFILE") != "" {
+		filenames = []string{os.Getenv("GOFILE")}
+	}
+	if len(filenames) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(filenames[0], ".go") + "_vvalidator.go"
+	}
+
+	if err := run(filenames, types, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "vvalidator-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(filenames, types []string, output string) error {
+	src, err := generateFiles(filenames, types)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, src, 0644)
+}
+
+// generateFiles parses the Go files and returns the generated source.
+func generateFiles(filenames, types []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	pkg := ""
+	for _, filename := range filenames {
+		f, err := parser.ParseFile(fset, filename, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if pkg != "" && f.Name.Name != pkg {
+			return nil, fmt.Errorf("%s: package %s, expected %s", filepath.Base(filename), f.Name.Name, pkg)
+		}
+		pkg = f.Name.Name
+		files = append(files, f)
+	}
+	return generate(pkg, files, types)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/syyongx/vvalidator"
+)
+
+// ruleSet is a rule string parsed by the vvalidator rule engine, so that
+// the generated code behaves the same.
+type ruleSet struct {
+	*vvalidator.Rule
+	// base is the rule string without its expr part.
+	base string
+}
+
+func parseRule(rule string) (*ruleSet, error) {
+	r, err := vvalidator.ParseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	rs := &ruleSet{Rule: r, base: rule}
+	if r.Expr != nil {
+		// The expression is the rest of the rule string.
+		base := strings.TrimSuffix(rule, r.Expr.String())
+		base = strings.TrimRight(strings.TrimSuffix(base, "expr:"), " \t\r\n")
+		rs.base = strings.TrimSuffix(base, "|")
+	}
+	return rs, nil
+}
+
+// ruleInt returns an integer bound as the rule engine compares with it,
+// ok is false if the rule has none.
+func ruleInt(bound string, min bool) (n int64, ok bool) {
+	if bound == "" {
+		return 0, false
+	}
+	if n, err := strconv.ParseInt(bound, 10, 64); err == nil {
+		return n, true
+	}
+	f, _ := strconv.ParseFloat(bound, 64)
+	if min {
+		f = math.Ceil(f)
+	} else {
+		f = math.Floor(f)
+	}
+	switch {
+	case f >= math.MaxInt64:
+		return math.MaxInt64, true
+	case f <= math.MinInt64:
+		return math.MinInt64, true
+	}
+	return int64(f), true
+}
+
+// ruleFloat returns a float bound, ok is false if the rule has none.
+func ruleFloat(bound string) (f float64, ok bool) {
+	if bound == "" {
+		return 0, false
+	}
+	f, _ = strconv.ParseFloat(bound, 64)
+	return f, true
+}
+
+// bounds returns the min and max message parameters of the rule set.
+func (rs *ruleSet) bounds() []string {
+	var params []string
+	for _, b := range [][2]string{{"min", rs.Min}, {"max", rs.Max}} {
+		if rs.Kind == "float" {
+			if f, ok := ruleFloat(b[1]); ok {
+				params = append(params, b[0], strconv.FormatFloat(f, 'f', -1, 64))
+			}
+		} else if n, ok := ruleInt(b[1], b[0] == "min"); ok {
+			params = append(params, b[0], strconv.FormatInt(n, 10))
+		}
+	}
+	return params
+}
+
+// builtinChecks are the builtin predicates keyed by rule name, they are
+// called directly by the generated code.
+var builtinChecks = make(map[string]vvalidator.Predicate)
+
+func init() {
+	for _, p := range vvalidator.Predicates() {
+		if p.Rule != "" {
+			builtinChecks[p.Rule] = p
+		}
+	}
+}
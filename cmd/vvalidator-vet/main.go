@@ -0,0 +1,15 @@
+// Command vvalidator-vet reports misuses of the vvalidator API, see
+// package vvalidatorcheck. Run it alone or as a go vet tool:
+//
+//	vvalidator-vet ./...
+//	go vet -vettool=$(which vvalidator-vet) ./...
+package main
+
+import (
+	"github.com/syyongx/vvalidator/vvalidatorcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(vvalidatorcheck.Analyzer)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/syyongx/vvalidator"
+	"gopkg.in/yaml.v3"
+)
+
+// config is a rule file.
+type config struct {
+	// Rules are the rule strings by field name.
+	Rules     map[string]string `yaml:"rules"`
+	Labels    map[string]string `yaml:"labels"`
+	Messages  map[string]string `yaml:"messages"`
+	Sensitive []string          `yaml:"sensitive"`
+	Strict    bool              `yaml:"strict"`
+	Trim      bool              `yaml:"trim"`
+	Locale    string            `yaml:"locale"`
+}
+
+// loadConfig reads a rule file, JSON files are read as YAML.
+func loadConfig(filename string) (*config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, errors.New("invalid rule file " + filename + ": " + err.Error())
+	}
+	if len(cfg.Rules) == 0 {
+		return nil, errors.New("invalid rule file " + filename + ": no rules")
+	}
+	// Catch invalid rule strings before reading any record.
+	if _, err := vvalidator.RulesSchema(cfg.Rules); err != nil {
+		return nil, errors.New("invalid rule file " + filename + ": " + err.Error())
+	}
+	return cfg, nil
+}
+
+// validator returns the validator configured by the rule file, the locale
+// is matched against the catalogs like an Accept-Language header.
+func (cfg *config) validator() (*vvalidator.Validator, error) {
+	v := vvalidator.New()
+	for key, label := range cfg.Labels {
+		v.Labels[key] = label
+	}
+	for id, msg := range cfg.Messages {
+		v.Messages[id] = msg
+	}
+	for _, key := range cfg.Sensitive {
+		v.Sensitive[key] = true
+	}
+	v.Strict = cfg.Strict
+	v.Trim = cfg.Trim
+	if cfg.Locale != "" {
+		v.Locale = vvalidator.MatchLocale(cfg.Locale, v.Locales())
+		if v.Locale == "" {
+			return nil, errors.New("unknown locale " + cfg.Locale + ", available: " + strings.Join(v.Locales(), ", "))
+		}
+	}
+	return v, nil
+}
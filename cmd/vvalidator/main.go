@@ -0,0 +1,171 @@
+// Command vvalidator validates records from files or stdin with the rule
+// strings of a rule file, so that the rules of an API also check data
+// imports and fixture files.
+//
+// Usage:
+//
+//	vvalidator -rules rules.yaml [-format auto|json|ndjson|csv] [-output text|json] [-locale en] [-workers n] [file ...]
+//
+// Records are JSON objects, alone, in arrays or as NDJSON, or the rows of a
+// CSV file with a header row, they are streamed with vvalidator.ValidateBatch.
+// The format is guessed from the file extension, stdin is read as JSON
+// unless -format is set. The rule file is JSON or YAML:
+//
+//	rules:
+//	  id: required|int64|min:1
+//	  email: required|email
+//	labels:
+//	  email: E-mail
+//	sensitive: [email]
+//	strict: false
+//	trim: true
+//	locale: en
+//
+// The exit status is 0 if all records are valid, 1 if a record is invalid
+// and 2 on usage or input errors.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/syyongx/vvalidator"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// result is an invalid row, as written by -output json.
+type result struct {
+	File   string            `json:"file"`
+	Row    int               `json:"row"`
+	Errors vvalidator.Errors `json:"errors"`
+}
+
+// run runs the command and returns its exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("vvalidator", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	rulesFile := flags.String("rules", "", "rule file in JSON or YAML, required")
+	format := flags.String("format", "auto", "record format: auto, json, ndjson or csv")
+	output := flags.String("output", "text", "output format: text or json")
+	locale := flags.String("locale", "", "locale of the error messages, e.g. zh or zh-CN, overrides the rule file")
+	workers := flags.Int("workers", 1, "number of goroutines validating records")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: vvalidator -rules file [-format auto|json|ndjson|csv] [-output text|json] [-locale locale] [-workers n] [file ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *rulesFile == "" || (*output != "text" && *output != "json") {
+		flags.Usage()
+		return 2
+	}
+	switch *format {
+	case "auto", "json", "ndjson", "csv":
+	default:
+		flags.Usage()
+		return 2
+	}
+
+	cfg, err := loadConfig(*rulesFile)
+	if err != nil {
+		fmt.Fprintln(stderr, "vvalidator:", err)
+		return 2
+	}
+	if *locale != "" {
+		cfg.Locale = *locale
+	}
+	v, err := cfg.validator()
+	if err != nil {
+		fmt.Fprintln(stderr, "vvalidator:", err)
+		return 2
+	}
+	c := &checker{v: v, rules: cfg.Rules, output: *output, workers: *workers, w: stdout}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, file := range files {
+		if err := c.checkFile(file, *format, stdin); err != nil {
+			fmt.Fprintln(stderr, "vvalidator:", err)
+			return 2
+		}
+	}
+	if *output == "text" {
+		if _, err := fmt.Fprintf(stdout, "%d of %d records invalid\n", c.invalid, c.records); err != nil {
+			fmt.Fprintln(stderr, "vvalidator:", err)
+			return 2
+		}
+	}
+	if c.invalid > 0 {
+		return 1
+	}
+	return 0
+}
+
+// checker validates files and writes the invalid rows.
+type checker struct {
+	v       *vvalidator.Validator
+	rules   map[string]string
+	output  string
+	workers int
+	w       io.Writer
+	records int
+	invalid int
+	// err is the first error writing the results.
+	err error
+}
+
+func (c *checker) checkFile(file, format string, stdin io.Reader) error {
+	name, r := file, stdin
+	if file == "-" {
+		name = "<stdin>"
+	} else {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	opts := vvalidator.BatchOptions{Format: vvalidator.BatchJSON, Workers: c.workers, Samples: -1}
+	if format == "csv" || (format == "auto" && strings.EqualFold(filepath.Ext(file), ".csv")) {
+		opts.Format = vvalidator.BatchCSV
+	}
+
+	enc := json.NewEncoder(c.w)
+	enc.SetEscapeHTML(false)
+	opts.Handler = func(row vvalidator.BatchRow) {
+		if c.err != nil {
+			return
+		}
+		if c.output == "json" {
+			c.err = enc.Encode(result{File: name, Row: row.Row, Errors: row.Errors})
+			return
+		}
+		for _, e := range row.Errors {
+			if _, c.err = fmt.Fprintf(c.w, "%s: row %d: %s\n", name, row.Row, e.Message); c.err != nil {
+				return
+			}
+		}
+	}
+	report, err := c.v.ValidateBatch(r, c.rules, opts)
+	if report != nil {
+		c.records += report.Records
+		c.invalid += report.Invalid
+	}
+	if err != nil {
+		return errors.New(name + ": " + err.Error())
+	}
+	return c.err
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	for _, tt := range []struct {
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			args:   []string{"-rules", "testdata/rules.yaml", "testdata/users.json"},
+			stdout: "0 of 2 records invalid\n",
+		},
+		{
+			args: []string{"-rules", "testdata/rules.yaml", "testdata/users.csv"},
+			code: 1,
+			stdout: "testdata/users.csv: row 2: age is too big (maximum is 150)\n" +
+				"testdata/users.csv: row 2: id is too small (minimum is 1)\n" +
+				"1 of 3 records invalid\n",
+		},
+		{
+			args: []string{"-rules", "testdata/rules.yaml", "-workers", "3", "testdata/users.csv"},
+			code: 1,
+			stdout: "testdata/users.csv: row 2: age is too big (maximum is 150)\n" +
+				"testdata/users.csv: row 2: id is too small (minimum is 1)\n" +
+				"1 of 3 records invalid\n",
+		},
+		{
+			args: []string{"-rules", "testdata/rules.yaml", "testdata/users.ndjson", "testdata/users.json"},
+			code: 1,
+			stdout: "testdata/users.ndjson: row 2: E-mail is required\n" +
+				"testdata/users.ndjson: row 3: id must be a valid interger\n" +
+				"2 of 5 records invalid\n",
+		},
+		{
+			args:  []string{"-rules", "testdata/rules.json", "-output", "json", "-format", "ndjson"},
+			stdin: `{"id": 0, "email": "x"}` + "\n" + `{"id": 1, "email": "a@example.com"}`,
+			code:  1,
+			stdout: `{"file":"<stdin>","row":1,"errors":[` +
+				`{"message":"email must be a valid email","code":400,"field":"email","path":"email","rule":"email","params":{"rule":"email"},"value":"[REDACTED]"},` +
+				`{"message":"id is too small (minimum is 1)","code":400,"field":"id","path":"id","rule":"min","params":{"min":"1"},"value":"0"}]}` + "\n",
+		},
+		{
+			args:   []string{"-rules", "testdata/rules.json", "-format", "csv", "-locale", "zh-CN"},
+			stdin:  "id,email\n0,a@example.com\n",
+			code:   1,
+			stdout: "<stdin>: row 1: id 太小（最小值为 1）\n1 of 1 records invalid\n",
+		},
+		{
+			args:   []string{"-rules", "testdata/rules.json", "-format", "csv", "-locale", "zh"},
+			stdin:  "id,email\n0,a@example.com\n",
+			code:   1,
+			stdout: "<stdin>: row 1: id 太小（最小值为 1）\n1 of 1 records invalid\n",
+		},
+		{
+			args:   []string{"-rules", "testdata/rules.json", "-locale", "xx"},
+			code:   2,
+			stderr: "vvalidator: unknown locale xx, available: en, zh-CN\n",
+		},
+		{
+			args:   []string{"-rules", "testdata/rules.yaml"},
+			stdin:  `{"id": 1, "email": "a@example.com"} 3`,
+			code:   2,
+			stderr: "vvalidator: <stdin>: row 2: not a JSON object\n",
+		},
+		{
+			args:   []string{"-rules", "testdata/rules.yaml", "-format", "csv"},
+			stdin:  "id,email\n1\n",
+			code:   2,
+			stderr: "vvalidator: <stdin>: record on line 2: wrong number of fields\n",
+		},
+		{
+			args:   []string{"-rules", "testdata/rules.yaml", "testdata/missing.json"},
+			code:   2,
+			stderr: "vvalidator: open testdata/missing.json: no such file or directory\n",
+		},
+		{
+			args:   []string{"-rules", "testdata/users.json"},
+			code:   2,
+			stderr: "vvalidator: invalid rule file testdata/users.json: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into main.config\n",
+		},
+		{
+			args: []string{"testdata/users.json"},
+			code: 2,
+		},
+	} {
+		var stdout, stderr bytes.Buffer
+		code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+		equal(t, tt.code, code)
+		equal(t, tt.stdout, stdout.String())
+		if tt.stderr != "" || code != 2 {
+			equal(t, tt.stderr, stderr.String())
+		}
+	}
+}
+
+// brokenPipe is a stdout whose reader went away.
+type brokenPipe struct{}
+
+func (brokenPipe) Write(p []byte) (int, error) { return 0, errors.New("write |1: broken pipe") }
+
+func TestRunWriteError(t *testing.T) {
+	for _, output := range []string{"text", "json"} {
+		var stderr bytes.Buffer
+		code := run([]string{"-rules", "testdata/rules.yaml", "-output", output, "testdata/users.csv"}, nil, brokenPipe{}, &stderr)
+		equal(t, 2, code)
+		equal(t, "vvalidator: write |1: broken pipe\n", stderr.String())
+	}
+}
+
+// Expected to be equal.
+func equal(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+	}
+}
@@ -0,0 +1,90 @@
+package vvalidator
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+	"sync"
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainsList string
+
+var disposableDomains = struct {
+	mu      sync.RWMutex
+	domains map[string]bool
+}{domains: make(map[string]bool)}
+
+func init() {
+	if err := LoadDisposableDomains(strings.NewReader(disposableDomainsList)); err != nil {
+		panic(err)
+	}
+}
+
+// LoadDisposableDomains replaces the disposable domains with the domains
+// read from r, one per line, "#" starts a comment. The built-in list only
+// holds common domains, see the disposable package for a full snapshot, or
+// load a maintained list to keep up with new domains.
+func LoadDisposableDomains(r io.Reader) error {
+	domains := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if domain := strings.TrimSpace(line); domain != "" {
+			domains[domainKey(domain)] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	disposableDomains.mu.Lock()
+	disposableDomains.domains = domains
+	disposableDomains.mu.Unlock()
+	return nil
+}
+
+// AddDisposableDomains adds domains to the disposable domains.
+func AddDisposableDomains(domains ...string) {
+	disposableDomains.mu.Lock()
+	defer disposableDomains.mu.Unlock()
+	for _, domain := range domains {
+		disposableDomains.domains[domainKey(domain)] = true
+	}
+}
+
+// IsDisposableDomain checks if the domain, or a domain it's a subdomain
+// of, is a disposable email domain.
+func IsDisposableDomain(domain string) bool {
+	domain = domainKey(domain)
+	disposableDomains.mu.RLock()
+	defer disposableDomains.mu.RUnlock()
+	for {
+		if disposableDomains.domains[domain] {
+			return true
+		}
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			return false
+		}
+		domain = domain[i+1:]
+	}
+}
+
+// IsDisposableEmail checks if the email address is at a disposable domain,
+// the address is not validated.
+func IsDisposableEmail(str string) bool {
+	return IsDisposableDomain(str[strings.LastIndexByte(str, '@')+1:])
+}
+
+// domainKey returns a domain lowercased and in ASCII, if it's valid.
+func domainKey(domain string) string {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	if ascii, err := asciiDomain(domain, true); err == nil {
+		return ascii
+	}
+	return domain
+}
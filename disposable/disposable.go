@@ -0,0 +1,26 @@
+// Package disposable holds a snapshot of about 120,000 disposable email
+// domains for vvalidator, which only embeds common ones to keep the binaries
+// of its users small. Programs that want the full list load it at start up:
+//
+//	if err := disposable.Load(); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// The snapshot is taken from github.com/AfterShip/email-verifier and is
+// subject to the MIT License, see domains.txt.
+package disposable
+
+import (
+	_ "embed"
+	"strings"
+
+	"github.com/syyongx/vvalidator"
+)
+
+//go:embed domains.txt
+var domains string
+
+// Load replaces the disposable domains of vvalidator with the snapshot.
+func Load() error {
+	return vvalidator.LoadDisposableDomains(strings.NewReader(domains))
+}
@@ -0,0 +1,24 @@
+package disposable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/syyongx/vvalidator"
+)
+
+func TestLoad(t *testing.T) {
+	equal(t, false, vvalidator.IsDisposableEmail("someone@0-mail.com"))
+	equal(t, nil, Load())
+	equal(t, true, vvalidator.IsDisposableEmail("someone@0-mail.com"))
+	equal(t, true, vvalidator.IsDisposableEmail("someone@mailinator.com"))
+	equal(t, false, vvalidator.IsDisposableEmail("someone@gmail.com"))
+}
+
+// Expected to be equal.
+func equal(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+	}
+}
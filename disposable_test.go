@@ -0,0 +1,28 @@
+package vvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDisposable(t *testing.T) {
+	equal(t, true, IsDisposableEmail("someone@mailinator.com"))
+	equal(t, true, IsDisposableEmail("someone@Eu.YOPmail.com"))
+	equal(t, false, IsDisposableEmail("someone@example.com"))
+	equal(t, false, IsDisposableEmail("someone@notmailinator.com"))
+	equal(t, false, IsDisposableEmail("someone@gmail.com"))
+	equal(t, true, IsDisposableDomain("mailinator.com."))
+	equal(t, false, IsDisposableDomain("com"))
+
+	defer LoadDisposableDomains(strings.NewReader(disposableDomainsList))
+	AddDisposableDomains("Wegwerf.example")
+	equal(t, true, IsDisposableEmail("a@wegwerf.example"))
+	AddDisposableDomains("bücher.example")
+	equal(t, true, IsDisposableEmail("a@xn--bcher-kva.example"))
+	equal(t, true, IsDisposableEmail("a@BÜCHER.example"))
+
+	err := LoadDisposableDomains(strings.NewReader("# updated list\nthrowaway.example # new\n\n"))
+	equal(t, nil, err)
+	equal(t, true, IsDisposableEmail("a@throwaway.example"))
+	equal(t, false, IsDisposableEmail("a@mailinator.com"))
+}
@@ -0,0 +1,372 @@
+package vvalidator
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultEmailOptions are the options of IsEmail and of the "email" rule.
+var DefaultEmailOptions = EmailOptions{AllowIDN: true, AllowSMTPUTF8: true}
+
+// EmailOptions configures ParseEmail, the zero value accepts the ASCII
+// dot-atom addresses of a host name.
+type EmailOptions struct {
+	// AllowQuoted accepts quoted local parts, e.g. "john doe"@example.com.
+	AllowQuoted bool
+	// AllowIDN accepts internationalized domain names, e.g. user@bücher.de.
+	AllowIDN bool
+	// AllowSMTPUTF8 accepts UTF-8 local parts (RFC 6531), e.g. 用户@example.com.
+	AllowSMTPUTF8 bool
+	// AllowIPLiteral accepts IP address literal domains, e.g. user@[192.0.2.1]
+	// or user@[IPv6:2001:db8::1], and bare IPv4 addresses, e.g. user@192.0.2.1.
+	AllowIPLiteral bool
+	// RequireTLD rejects domains without a top-level domain, e.g. user@localhost.
+	RequireTLD bool
+	// KnownTLD rejects domains whose top-level domain is not in the Public
+	// Suffix List, e.g. user@example.invalid, see IsTLD.
+	KnownTLD bool
+	// MaxLocalLength, MaxDomainLength and MaxLength are the maximum lengths
+	// in bytes of the local part, the domain and the address, 0 means the
+	// limits of RFC 5321: 64, 255 and 254. Domains are measured in ASCII.
+	MaxLocalLength, MaxDomainLength, MaxLength int
+}
+
+// Email is a parsed email address.
+type Email struct {
+	// Local is the local part as written, quotes included.
+	Local string
+	// Domain is the domain as written, IP literals included.
+	Domain string
+	// ASCIIDomain is the domain with its internationalized labels in
+	// Punycode, e.g. "xn--bcher-kva.de" for "bücher.de".
+	ASCIIDomain string
+}
+
+// String returns the address.
+func (e *Email) String() string {
+	return e.Local + "@" + e.Domain
+}
+
+// ParseEmail parses an email address (RFC 5322 addr-spec, RFC 6531 for
+// UTF-8) into its local part and domain. Comments, folding white space and
+// obsolete forms are rejected.
+func ParseEmail(addr string, opts EmailOptions) (*Email, error) {
+	at := strings.LastIndexByte(addr, '@')
+	if at < 0 {
+		return nil, emailError("missing @")
+	}
+	e := &Email{Local: addr[:at], Domain: addr[at+1:]}
+	if !utf8.ValidString(addr) {
+		return nil, emailError("invalid UTF-8")
+	}
+	if err := parseLocal(e.Local, opts); err != nil {
+		return nil, err
+	}
+	ascii, err := parseEmailDomain(e.Domain, opts)
+	if err != nil {
+		return nil, err
+	}
+	e.ASCIIDomain = ascii
+
+	maxLocal, maxDomain, max := opts.MaxLocalLength, opts.MaxDomainLength, opts.MaxLength
+	if maxLocal == 0 {
+		maxLocal = 64
+	}
+	if maxDomain == 0 {
+		maxDomain = 255
+	}
+	if max == 0 {
+		max = 254
+	}
+	switch {
+	case len(e.Local) > maxLocal:
+		return nil, emailError("local part is too long (maximum is " + strconv.Itoa(maxLocal) + " bytes)")
+	case len(ascii) > maxDomain:
+		return nil, emailError("domain is too long (maximum is " + strconv.Itoa(maxDomain) + " bytes)")
+	case len(e.Local)+1+len(ascii) > max:
+		return nil, emailError("address is too long (maximum is " + strconv.Itoa(max) + " bytes)")
+	}
+	return e, nil
+}
+
+func emailError(reason string) error {
+	return errors.New("invalid email address: " + reason)
+}
+
+// parseLocal checks a dot-atom or quoted-string local part.
+func parseLocal(local string, opts EmailOptions) error {
+	if local == "" {
+		return emailError("empty local part")
+	}
+	if local[0] != '"' {
+		for _, atom := range strings.Split(local, ".") {
+			if atom == "" {
+				return emailError("leading, trailing or consecutive dots in local part")
+			}
+			for _, r := range atom {
+				if !isAtext(r) && !(opts.AllowSMTPUTF8 && isUTF8Text(r)) {
+					return emailError("invalid character " + strconv.QuoteRune(r) + " in local part")
+				}
+			}
+		}
+		return nil
+	}
+
+	if !opts.AllowQuoted {
+		return emailError("quoted local part is not allowed")
+	}
+	if len(local) < 2 || local[len(local)-1] != '"' {
+		return emailError("unterminated quoted local part")
+	}
+	quoted := local[1 : len(local)-1]
+	for i := 0; i < len(quoted); {
+		r, size := utf8.DecodeRuneInString(quoted[i:])
+		i += size
+		switch {
+		case r == '\\':
+			if i == len(quoted) {
+				return emailError("unterminated quoted pair in local part")
+			}
+			r, size = utf8.DecodeRuneInString(quoted[i:])
+			i += size
+			if !(r == ' ' || r == '\t' || (r > ' ' && r < utf8.RuneSelf && r != 0x7f) || (opts.AllowSMTPUTF8 && isUTF8Text(r))) {
+				return emailError("invalid quoted pair in local part")
+			}
+		case r == '"':
+			return emailError("unescaped quote in local part")
+		case r == ' ' || r == '\t' || (r > ' ' && r < utf8.RuneSelf && r != 0x7f):
+		case opts.AllowSMTPUTF8 && isUTF8Text(r):
+		default:
+			return emailError("invalid character " + strconv.QuoteRune(r) + " in local part")
+		}
+	}
+	return nil
+}
+
+// isAtext reports whether r is an atext character of RFC 5322.
+func isAtext(r rune) bool {
+	return r < utf8.RuneSelf && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r))
+}
+
+// isUTF8Text reports whether r is a non-ASCII character allowed by RFC 6531.
+func isUTF8Text(r rune) bool {
+	return r >= utf8.RuneSelf && r != utf8.RuneError && !unicode.IsControl(r) && !unicode.IsSpace(r)
+}
+
+// parseEmailDomain checks the domain of an address and returns it in ASCII.
+func parseEmailDomain(domain string, opts EmailOptions) (string, error) {
+	if domain == "" {
+		return "", emailError("empty domain")
+	}
+	if domain[0] == '[' {
+		if !opts.AllowIPLiteral {
+			return "", emailError("IP literal domain is not allowed")
+		}
+		if !isIPLiteral(domain) {
+			return "", emailError("invalid IP literal " + domain)
+		}
+		return domain, nil
+	}
+
+	ascii, err := asciiDomain(domain, opts.AllowIDN)
+	if err != nil {
+		return "", emailError(err.Error())
+	}
+	// Bare IP addresses, e.g. a@192.0.2.1, are IP literals without brackets,
+	// and a domain's top-level domain is never all digits.
+	if !opts.AllowIPLiteral {
+		if net.ParseIP(ascii) != nil || strings.Trim(ascii[strings.LastIndexByte(ascii, '.')+1:], "0123456789") == "" {
+			return "", emailError("IP address domain is not allowed")
+		}
+	}
+	if opts.RequireTLD {
+		if !hasTLD(ascii) {
+			return "", emailError("domain has no top-level domain")
+		}
+	}
+	if opts.KnownTLD {
+		if tld := ascii[strings.LastIndexByte(ascii, '.')+1:]; !IsTLD(tld) {
+			return "", emailError("unknown top-level domain " + tld)
+		}
+	}
+	return ascii, nil
+}
+
+// isIPLiteral reports whether s is an address literal such as "[192.0.2.1]"
+// or "[IPv6:2001:db8::1]".
+func isIPLiteral(s string) bool {
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return false
+	}
+	lit := s[1 : len(s)-1]
+	if strings.HasPrefix(lit, "IPv6:") {
+		return strings.Contains(lit, ":") && net.ParseIP(lit[len("IPv6:"):]) != nil
+	}
+	return !strings.Contains(lit, ":") && net.ParseIP(lit) != nil
+}
+
+// asciiDomain checks the labels of a domain name and returns it with its
+// internationalized labels in Punycode, if idn is set.
+func asciiDomain(domain string, idn bool) (string, error) {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		ascii, err := asciiLabel(label, idn)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = ascii
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// asciiLabel checks a domain label: letters, digits and hyphens not at
+// either end, at most 63 bytes in ASCII. Internationalized labels, as
+// Unicode or as "xn--" Punycode, need idn.
+func asciiLabel(label string, idn bool) (string, error) {
+	if label == "" {
+		return "", errors.New("empty domain label")
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return "", errors.New("domain label " + label + " starts or ends with a hyphen")
+	}
+
+	ascii := label
+	unicodeLabel := false
+	for _, r := range label {
+		if r >= utf8.RuneSelf {
+			unicodeLabel = true
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) {
+				return "", errors.New("invalid character " + strconv.QuoteRune(r) + " in domain label")
+			}
+		} else if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return "", errors.New("invalid character " + strconv.QuoteRune(r) + " in domain label")
+		}
+	}
+	if unicodeLabel {
+		if !idn {
+			return "", errors.New("internationalized domain label " + label + " is not allowed")
+		}
+		encoded, err := punycodeEncode(strings.ToLower(label))
+		if err != nil {
+			return "", err
+		}
+		ascii = "xn--" + encoded
+	} else if len(label) >= 4 && strings.EqualFold(label[:4], "xn--") {
+		if !idn {
+			return "", errors.New("internationalized domain label " + label + " is not allowed")
+		}
+		// The Punycode must decode to a valid label that encodes back to it.
+		decoded, err := punycodeDecode(label[4:])
+		if err == nil && !isASCII(decoded) {
+			var encoded string
+			if encoded, err = asciiLabel(decoded, true); err == nil && !strings.EqualFold(encoded, label) {
+				err = errors.New("not canonical")
+			}
+		}
+		if err != nil || isASCII(decoded) {
+			return "", errors.New("invalid punycode domain label " + label)
+		}
+	}
+	if len(ascii) > 63 {
+		return "", errors.New("domain label " + label + " is too long (maximum is 63 bytes)")
+	}
+	return ascii, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// EmailProvider describes how a mail provider delivers the local parts of
+// its domain, for NormalizeEmail.
+type EmailProvider struct {
+	// Lowercase makes the local part case-insensitive.
+	Lowercase bool
+	// IgnoreDots removes the dots of the local part, e.g. j.doe => jdoe.
+	IgnoreDots bool
+	// TagSeparator starts the tag of a local part, e.g. "+" for jdoe+news.
+	TagSeparator string
+	// Domain is the canonical domain, e.g. "gmail.com" for "googlemail.com".
+	Domain string
+}
+
+// emailProviders are the providers of NormalizeEmail by lowercase ASCII
+// domain, see SetEmailProvider.
+var emailProviders = struct {
+	mu        sync.RWMutex
+	providers map[string]EmailProvider
+}{providers: map[string]EmailProvider{
+	"gmail.com":      {Lowercase: true, IgnoreDots: true, TagSeparator: "+"},
+	"googlemail.com": {Lowercase: true, IgnoreDots: true, TagSeparator: "+", Domain: "gmail.com"},
+	"outlook.com":    {Lowercase: true, TagSeparator: "+"},
+	"hotmail.com":    {Lowercase: true, TagSeparator: "+"},
+	"live.com":       {Lowercase: true, TagSeparator: "+"},
+	"icloud.com":     {Lowercase: true, TagSeparator: "+"},
+	"me.com":         {Lowercase: true, TagSeparator: "+", Domain: "icloud.com"},
+	"mac.com":        {Lowercase: true, TagSeparator: "+", Domain: "icloud.com"},
+	"fastmail.com":   {Lowercase: true, TagSeparator: "+"},
+	"proton.me":      {Lowercase: true, TagSeparator: "+"},
+	"protonmail.com": {Lowercase: true, TagSeparator: "+"},
+	"pm.me":          {Lowercase: true, TagSeparator: "+"},
+	"yahoo.com":      {Lowercase: true, TagSeparator: "-"},
+}}
+
+// SetEmailProvider adds or overrides the provider of a domain for
+// NormalizeEmail, e.g. the mail domain of your company.
+func SetEmailProvider(domain string, p EmailProvider) {
+	emailProviders.mu.Lock()
+	defer emailProviders.mu.Unlock()
+	emailProviders.providers[domainKey(domain)] = p
+}
+
+// DeleteEmailProvider removes the provider of a domain, NormalizeEmail
+// leaves the local parts of the domain as they are.
+func DeleteEmailProvider(domain string) {
+	emailProviders.mu.Lock()
+	defer emailProviders.mu.Unlock()
+	delete(emailProviders.providers, domainKey(domain))
+}
+
+// NormalizeEmail returns the canonical form of an email address, so that
+// the addresses of one mailbox compare equal: the domain is lowercased and
+// in ASCII, and the rules of its provider are applied to the local part,
+// e.g. "J.Doe+news@GoogleMail.com" => "jdoe@gmail.com", see SetEmailProvider.
+func NormalizeEmail(addr string) (string, error) {
+	e, err := ParseEmail(addr, DefaultEmailOptions)
+	if err != nil {
+		return "", err
+	}
+	local, domain := e.Local, strings.ToLower(e.ASCIIDomain)
+	emailProviders.mu.RLock()
+	p, ok := emailProviders.providers[domain]
+	emailProviders.mu.RUnlock()
+	if !ok {
+		return local + "@" + domain, nil
+	}
+	if p.TagSeparator != "" {
+		if i := strings.Index(local, p.TagSeparator); i > 0 {
+			local = local[:i]
+		}
+	}
+	if p.IgnoreDots {
+		local = strings.Replace(local, ".", "", -1)
+	}
+	if p.Lowercase {
+		local = strings.ToLower(local)
+	}
+	if p.Domain != "" {
+		domain = p.Domain
+	}
+	return local + "@" + domain, nil
+}
@@ -0,0 +1,110 @@
+package vvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEmail(t *testing.T) {
+	e, err := ParseEmail("John.Doe+tag@Example.com", EmailOptions{})
+	equal(t, nil, err)
+	equal(t, &Email{Local: "John.Doe+tag", Domain: "Example.com", ASCIIDomain: "Example.com"}, e)
+	equal(t, "John.Doe+tag@Example.com", e.String())
+
+	e, err = ParseEmail("用户@bücher.de", DefaultEmailOptions)
+	equal(t, nil, err)
+	equal(t, "xn--bcher-kva.de", e.ASCIIDomain)
+	e, err = ParseEmail(`"john \"doe\"@home"@[IPv6:2001:db8::1]`, EmailOptions{AllowQuoted: true, AllowIPLiteral: true})
+	equal(t, nil, err)
+	equal(t, `"john \"doe\"@home"`, e.Local)
+	equal(t, "[IPv6:2001:db8::1]", e.Domain)
+
+	for _, tt := range []struct {
+		addr string
+		opts EmailOptions
+		err  string
+	}{
+		{"a.b@example.com", EmailOptions{}, ""},
+		{"!#$%&'*+-/=?^_`{|}~@example.com", EmailOptions{}, ""},
+		{"a@localhost", EmailOptions{}, ""},
+		{"a@xn--bcher-kva.de", EmailOptions{AllowIDN: true}, ""},
+		{"a@[192.0.2.1]", EmailOptions{AllowIPLiteral: true}, ""},
+		{"a@192.0.2.1", EmailOptions{AllowIPLiteral: true}, ""},
+		{`"a b"@example.com`, EmailOptions{AllowQuoted: true}, ""},
+		{`"ü"@example.com`, EmailOptions{AllowQuoted: true, AllowSMTPUTF8: true}, ""},
+		{"a.example.com", EmailOptions{}, "missing @"},
+		{"@example.com", EmailOptions{}, "empty local part"},
+		{"a@", EmailOptions{}, "empty domain"},
+		{"a..b@x", EmailOptions{}, "leading, trailing or consecutive dots in local part"},
+		{".a@x", EmailOptions{}, "leading, trailing or consecutive dots in local part"},
+		{"a.@x", EmailOptions{}, "leading, trailing or consecutive dots in local part"},
+		{"a b@x", EmailOptions{}, `invalid character ' ' in local part`},
+		{"a@b@x", EmailOptions{}, `invalid character '@' in local part`},
+		{"用户@example.com", EmailOptions{}, `invalid character '用' in local part`},
+		{`"a b"@example.com`, EmailOptions{}, "quoted local part is not allowed"},
+		{`"a"b"@example.com`, EmailOptions{AllowQuoted: true}, "unescaped quote in local part"},
+		{`"a\"@example.com`, EmailOptions{AllowQuoted: true}, "unterminated quoted pair in local part"},
+		{`"ü"@example.com`, EmailOptions{AllowQuoted: true}, `invalid character 'ü' in local part`},
+		{"a@bücher.de", EmailOptions{}, "internationalized domain label bücher is not allowed"},
+		{"a@xn--bcher-kva.de", EmailOptions{}, "internationalized domain label xn--bcher-kva is not allowed"},
+		{"a@xn--a.de", EmailOptions{AllowIDN: true}, "invalid punycode domain label xn--a"},
+		{"a@xn--abc-.de", EmailOptions{AllowIDN: true}, "domain label xn--abc- starts or ends with a hyphen"},
+		{"a@-example.com", EmailOptions{}, "domain label -example starts or ends with a hyphen"},
+		{"a@exa_mple.com", EmailOptions{}, `invalid character '_' in domain label`},
+		{"a@example..com", EmailOptions{}, "empty domain label"},
+		{"a@example.com.", EmailOptions{}, "empty domain label"},
+		{"a@[192.0.2.1]", EmailOptions{}, "IP literal domain is not allowed"},
+		{"a@[2001:db8::1]", EmailOptions{AllowIPLiteral: true}, "invalid IP literal [2001:db8::1]"},
+		{"a@[IPv6:192.0.2.1]", EmailOptions{AllowIPLiteral: true}, ""},
+		{"a@localhost", EmailOptions{RequireTLD: true}, "domain has no top-level domain"},
+		{"a@192.0.2.1", EmailOptions{}, "IP address domain is not allowed"},
+		{"a@192.168.0.1", EmailOptions{RequireTLD: true}, "IP address domain is not allowed"},
+		{"a@example.123", EmailOptions{}, "IP address domain is not allowed"},
+		{"a@192.0.2.1", EmailOptions{AllowIPLiteral: true, RequireTLD: true}, "domain has no top-level domain"},
+		{"a@example.com", EmailOptions{RequireTLD: true}, ""},
+		{strings.Repeat("a", 65) + "@x", EmailOptions{}, "local part is too long (maximum is 64 bytes)"},
+		{"ab@x", EmailOptions{MaxLocalLength: 1}, "local part is too long (maximum is 1 bytes)"},
+		{"a@" + strings.Repeat("a", 64) + ".com", EmailOptions{}, "domain label " + strings.Repeat("a", 64) + " is too long (maximum is 63 bytes)"},
+		{"a@" + strings.Repeat(strings.Repeat("a", 63)+".", 4) + "com", EmailOptions{}, "domain is too long (maximum is 255 bytes)"},
+		{strings.Repeat("a", 64) + "@" + strings.Repeat(strings.Repeat("a", 63)+".", 3) + "com", EmailOptions{}, "address is too long (maximum is 254 bytes)"},
+		{"a@\xff", EmailOptions{}, "invalid UTF-8"},
+	} {
+		_, err := ParseEmail(tt.addr, tt.opts)
+		if tt.err == "" {
+			equal(t, nil, err)
+		} else if err == nil {
+			t.Errorf("%s: expected error %s", tt.addr, tt.err)
+		} else {
+			equal(t, "invalid email address: "+tt.err, err.Error())
+		}
+	}
+
+	equal(t, true, IsEmail("用户@bücher.de"))
+	equal(t, false, IsEmail("a..b@x"))
+	equal(t, false, IsEmail(`"a b"@example.com`))
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	for addr, want := range map[string]string{
+		"J.Doe+news@GoogleMail.com": "jdoe@gmail.com",
+		"j.doe@gmail.com":           "jdoe@gmail.com",
+		"+tag@gmail.com":            "+tag@gmail.com",
+		"John.Doe+x@Example.COM":    "John.Doe+x@example.com",
+		"jdoe-news@yahoo.com":       "jdoe@yahoo.com",
+		"Ann+1@Me.com":              "ann@icloud.com",
+		"ann@Bücher.de":             "ann@xn--bcher-kva.de",
+	} {
+		got, err := NormalizeEmail(addr)
+		equal(t, nil, err)
+		equal(t, want, got)
+	}
+	_, err := NormalizeEmail("a..b@gmail.com")
+	equal(t, "invalid email address: leading, trailing or consecutive dots in local part", err.Error())
+
+	SetEmailProvider("Example.org", EmailProvider{TagSeparator: "_"})
+	got, _ := NormalizeEmail("Ann_news@example.org")
+	equal(t, "Ann@example.org", got)
+	DeleteEmailProvider("example.org")
+	got, _ = NormalizeEmail("Ann_news@example.org")
+	equal(t, "Ann_news@example.org", got)
+}
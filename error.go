@@ -1,13 +1,33 @@
 package vvalidator
 
+import (
+	"strings"
+)
+
 // DefaultCode default parameter error code
 var DefaultCode = 400
 
-// Error error struct
+// RedactedValue replaces the value of sensitive fields in errors.
+const RedactedValue = "[REDACTED]"
+
+// Error error struct, it marshals to JSON as
+//
+//	{"message":"uid is too big (maximum is 10)","code":400,"field":"uid","path":"uid","rule":"max","params":{"max":"10"},"value":"123"}
 type Error struct {
-	Message       string
-	Code          int
-	CustomMessage string
+	Message       string `json:"message"`
+	Code          int    `json:"code"`
+	CustomMessage string `json:"custom_message,omitempty"`
+	// Field is the key of the invalid field.
+	Field string `json:"field,omitempty"`
+	// Path locates the field in the input, e.g. "items[0].price", it's the
+	// key for flat data.
+	Path string `json:"path,omitempty"`
+	// Rule is the failed rule, e.g. "max" or "email".
+	Rule string `json:"rule,omitempty"`
+	// Params are the rule parameters by name, e.g. "min" and "max".
+	Params map[string]string `json:"params,omitempty"`
+	// Value is the invalid value, or RedactedValue.
+	Value string `json:"value,omitempty"`
 }
 
 // NewError returns the instance of Error
@@ -18,3 +38,59 @@ func NewError(message string, code int, customMessage string) Error {
 		CustomMessage: customMessage,
 	}
 }
+
+// Error returns the error message.
+func (e Error) Error() string {
+	return e.Message
+}
+
+// Redact returns the error with its value replaced by RedactedValue.
+func (e Error) Redact() Error {
+	if e.Value != "" {
+		e.Value = RedactedValue
+	}
+	return e
+}
+
+// Errors is a list of validation errors, e.g. one per invalid field.
+type Errors []Error
+
+// Error returns the error messages separated by "; ".
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Redact returns the errors with their values replaced by RedactedValue.
+func (es Errors) Redact() Errors {
+	redacted := make(Errors, len(es))
+	for i, e := range es {
+		redacted[i] = e.Redact()
+	}
+	return redacted
+}
+
+// add appends err if it's a validation error, other errors are returned.
+func (es Errors) add(err error) (Errors, error) {
+	switch e := err.(type) {
+	case nil:
+	case Error:
+		es = append(es, e)
+	case Errors:
+		es = append(es, e...)
+	default:
+		return es, err
+	}
+	return es, nil
+}
+
+// err returns es as an error, nil if es is empty.
+func (es Errors) err() error {
+	if len(es) == 0 {
+		return nil
+	}
+	return es
+}
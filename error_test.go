@@ -0,0 +1,25 @@
+package vvalidator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	params := map[string]string{
+		"uid":      "123",
+		"password": "secret",
+	}
+
+	v := New()
+	v.Sensitive["password"] = true
+	err := v.ValidateRules(params, map[string]string{"uid": "int|max:10", "password": "min:8"})
+	b, _ := json.Marshal(err)
+	equal(t, `[{"message":"password is too short (minimum is 8 characters)","code":400,"field":"password","path":"password","rule":"min_length","params":{"min":"8"},"value":"[REDACTED]"},`+
+		`{"message":"uid is too big (maximum is 10)","code":400,"field":"uid","path":"uid","rule":"max","params":{"max":"10"},"value":"123"}]`, string(b))
+
+	var errs Errors
+	equal(t, nil, json.Unmarshal(b, &errs))
+	equal(t, err, errs)
+	equal(t, "[REDACTED]", errs.Redact()[1].Value)
+}
@@ -0,0 +1,797 @@
+package vvalidator
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxExprDepth limits the nesting of an expression so that hostile input
+// cannot exhaust the stack while parsing or evaluating.
+const maxExprDepth = 64
+
+// exprCache holds the expressions compiled by ValidateExpr.
+var exprCache = newCache(maxCacheSize)
+
+// Expr is a compiled expression over the fields of a record.
+// An Expr is immutable and may be evaluated concurrently.
+//
+// The language supports:
+//
+//	literals:    123, 1.5, "str", 'str', true, false, null, ["a", "b"]
+//	variables:   field names, e.g. price, user.name (missing fields are null)
+//	operators:   || && ! == != < <= > >= in + - * / %
+//	functions:   len(s), matches(s, pattern) and the predicates of this
+//	             package, e.g. IsEmail(s), IsHash(s, algo), IsTime(s, layout).
+//
+// Field values are strings; they are compared and computed as numbers
+// when both operands are numeric and as strings otherwise.
+type Expr struct {
+	source string
+	root   exprNode
+}
+
+// CompileExpr parses an expression so that it can be evaluated many times.
+func CompileExpr(source string) (*Expr, error) {
+	p := &exprParser{lexer: exprLexer{src: source}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected " + p.tok.String())
+	}
+	return &Expr{source: source, root: root}, nil
+}
+
+// MustCompileExpr is like CompileExpr but panics if the expression cannot be parsed.
+func MustCompileExpr(source string) *Expr {
+	e, err := CompileExpr(source)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// String returns the source text of the expression.
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression with the given fields as variables.
+// The result is nil, bool, float64, string or []interface{}.
+func (e *Expr) Eval(fields map[string]string) (interface{}, error) {
+	return e.root.eval(fields)
+}
+
+// Match evaluates the expression and reports whether it holds.
+// It returns an error if the result is not a bool.
+func (e *Expr) Match(fields map[string]string) (bool, error) {
+	v, err := e.Eval(fields)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, errors.New("expression must evaluate to bool: " + e.source)
+	}
+	return b, nil
+}
+
+// ValidateExpr validate that data satisfies the expression, key names the field
+// reported on failure.
+func (v *Validator) ValidateExpr(data interface{}, key, expr string) error {
+	fields, ok := data.(map[string]string)
+	if !ok {
+		return v.error(MsgDataType, key, "", "types", "map[string]string")
+	}
+	var e *Expr
+	if cached, found := exprCache.load(expr); found {
+		e = cached.(*Expr)
+	} else {
+		var err error
+		if e, err = CompileExpr(expr); err != nil {
+			return err
+		}
+		exprCache.store(expr, e)
+	}
+	// Evaluation errors come from the data, e.g. a non-numeric operand.
+	if ok, err := e.Match(v.trim(fields)); err != nil || !ok {
+		return v.error(MsgInvalid, key, fields[key])
+	}
+	return nil
+}
+
+// ValidateExprp validate expression with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateExprp(data interface{}, key, expr string, code int, message string) {
+	if err := v.ValidateExpr(data, key, expr); err != nil {
+		v.panicError(err, code, message)
+	}
+}
+
+// exprFunc is a function callable from expressions.
+type exprFunc struct {
+	arity int
+	fn    func(args []interface{}) (interface{}, error)
+}
+
+// exprFuncs is the fixed set of functions available to expressions, the
+// predicates are added by init.
+var exprFuncs = map[string]exprFunc{
+	"len": {1, func(args []interface{}) (interface{}, error) {
+		return float64(utf8.RuneCountInString(exprString(args[0]))), nil
+	}},
+	"matches": {2, func(args []interface{}) (interface{}, error) {
+		re, err := regexp.Compile(exprString(args[1]))
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(exprString(args[0])), nil
+	}},
+}
+
+func init() {
+	for _, p := range predicates {
+		p := p
+		if p.Param {
+			exprFuncs[p.Func] = exprFunc{2, func(args []interface{}) (interface{}, error) {
+				return p.Check(exprString(args[0]), exprString(args[1])), nil
+			}}
+			continue
+		}
+		exprFuncs[p.Func] = exprFunc{1, func(args []interface{}) (interface{}, error) {
+			return p.Check(exprString(args[0]), ""), nil
+		}}
+	}
+}
+
+// Token kinds.
+const (
+	tokEOF = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type exprToken struct {
+	kind int
+	text string
+	num  float64
+	pos  int
+}
+
+func (t exprToken) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	}
+	return "'" + t.text + "'"
+}
+
+type exprLexer struct {
+	src string
+	pos int
+}
+
+// scan returns the next token of the source.
+func (l *exprLexer) scan() (exprToken, error) {
+	for l.pos < len(l.src) && strings.IndexByte(" \t\r\n", l.src[l.pos]) >= 0 {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return exprToken{kind: tokEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.' && l.pos+1 < len(l.src) && l.src[l.pos+1] >= '0' && l.src[l.pos+1] <= '9':
+		for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '.') {
+			l.pos++
+		}
+		if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+			l.pos++
+			if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+				l.pos++
+			}
+			for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+				l.pos++
+			}
+		}
+		text := l.src[start:l.pos]
+		n, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return exprToken{}, exprError(start, "invalid number "+text)
+		}
+		return exprToken{kind: tokNumber, text: text, num: n, pos: start}, nil
+	case c == '"' || c == '\'':
+		var sb strings.Builder
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] != c {
+			ch := l.src[l.pos]
+			if ch == '\\' && l.pos+1 < len(l.src) {
+				l.pos++
+				switch l.src[l.pos] {
+				case 'n':
+					ch = '\n'
+				case 't':
+					ch = '\t'
+				default:
+					ch = l.src[l.pos]
+				}
+			}
+			sb.WriteByte(ch)
+			l.pos++
+		}
+		if l.pos >= len(l.src) {
+			return exprToken{}, exprError(start, "unterminated string")
+		}
+		l.pos++
+		return exprToken{kind: tokString, text: sb.String(), pos: start}, nil
+	case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		for l.pos < len(l.src) && isIdentByte(l.src[l.pos]) {
+			l.pos++
+		}
+		return exprToken{kind: tokIdent, text: l.src[start:l.pos], pos: start}, nil
+	}
+
+	for _, op := range []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ","} {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return exprToken{kind: tokOp, text: op, pos: start}, nil
+		}
+	}
+	return exprToken{}, exprError(start, "unexpected character "+strconv.QuoteRune(rune(c)))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '.' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func exprError(pos int, msg string) error {
+	return errors.New("expression: " + msg + " at position " + strconv.Itoa(pos))
+}
+
+// exprParser is a recursive descent parser, lowest precedence first:
+// ||, &&, comparison and in, + -, * / %, unary ! -.
+type exprParser struct {
+	lexer exprLexer
+	tok   exprToken
+}
+
+func (p *exprParser) next() error {
+	tok, err := p.lexer.scan()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *exprParser) errorf(msg string) error {
+	return exprError(p.tok.pos, msg)
+}
+
+func (p *exprParser) isOp(ops ...string) bool {
+	if p.tok.kind != tokOp {
+		return false
+	}
+	for _, op := range ops {
+		if p.tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *exprParser) expect(op string) error {
+	if !p.isOp(op) {
+		return p.errorf("expected '" + op + "', found " + p.tok.String())
+	}
+	return p.next()
+}
+
+func (p *exprParser) parseOr(depth int) (exprNode, error) {
+	if depth > maxExprDepth {
+		return nil, p.errorf("expression nested too deeply")
+	}
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprLogical{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd(depth int) (exprNode, error) {
+	left, err := p.parseCompare(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseCompare(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprLogical{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseCompare(depth int) (exprNode, error) {
+	left, err := p.parseAdd(depth)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokIdent && p.tok.text == "in" {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAdd(depth)
+		if err != nil {
+			return nil, err
+		}
+		return &exprIn{left: left, right: right}, nil
+	}
+	if p.isOp("==", "!=", "<", "<=", ">", ">=") {
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAdd(depth)
+		if err != nil {
+			return nil, err
+		}
+		return &exprBinary{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAdd(depth int) (exprNode, error) {
+	left, err := p.parseMul(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+", "-") {
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseMul(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseMul(depth int) (exprNode, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*", "/", "%") {
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary(depth int) (exprNode, error) {
+	if p.isOp("!", "-") {
+		if depth > maxExprDepth {
+			return nil, p.errorf("expression nested too deeply")
+		}
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &exprUnary{op: op, operand: operand}, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *exprParser) parsePrimary(depth int) (exprNode, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		return &exprLiteral{tok.num}, p.next()
+	case tokString:
+		return &exprLiteral{tok.text}, p.next()
+	case tokIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch tok.text {
+		case "true":
+			return &exprLiteral{true}, nil
+		case "false":
+			return &exprLiteral{false}, nil
+		case "null":
+			return &exprLiteral{nil}, nil
+		}
+		if !p.isOp("(") {
+			return &exprVar{tok.text}, nil
+		}
+		fn, ok := exprFuncs[tok.text]
+		if !ok {
+			return nil, exprError(tok.pos, "unknown function "+tok.text)
+		}
+		args, err := p.parseList(")", depth)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != fn.arity {
+			return nil, exprError(tok.pos, tok.text+" expects "+strconv.Itoa(fn.arity)+" argument(s)")
+		}
+		// Constant patterns are compiled once.
+		if lit, ok := args[len(args)-1].(*exprLiteral); ok && tok.text == "matches" {
+			re, err := regexp.Compile(exprString(lit.value))
+			if err != nil {
+				return nil, exprError(tok.pos, "invalid pattern: "+err.Error())
+			}
+			return &exprMatch{subject: args[0], re: re}, nil
+		}
+		return &exprCall{name: tok.text, fn: fn, args: args}, nil
+	case tokOp:
+		switch tok.text {
+		case "(":
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			node, err := p.parseOr(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		case "[":
+			items, err := p.parseList("]", depth)
+			if err != nil {
+				return nil, err
+			}
+			return &exprList{items}, nil
+		}
+	}
+	return nil, p.errorf("unexpected " + tok.String())
+}
+
+// parseList parses comma separated expressions up to the closing op,
+// the current token being the opening one.
+func (p *exprParser) parseList(closing string, depth int) ([]exprNode, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	var items []exprNode
+	for !p.isOp(closing) {
+		if len(items) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		item, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, p.next()
+}
+
+// exprNode is a node of the expression tree.
+type exprNode interface {
+	eval(fields map[string]string) (interface{}, error)
+}
+
+type exprLiteral struct {
+	value interface{}
+}
+
+func (n *exprLiteral) eval(fields map[string]string) (interface{}, error) {
+	return n.value, nil
+}
+
+type exprVar struct {
+	name string
+}
+
+func (n *exprVar) eval(fields map[string]string) (interface{}, error) {
+	if v, ok := fields[n.name]; ok {
+		return v, nil
+	}
+	return nil, nil
+}
+
+type exprList struct {
+	items []exprNode
+}
+
+func (n *exprList) eval(fields map[string]string) (interface{}, error) {
+	vals := make([]interface{}, len(n.items))
+	for i, item := range n.items {
+		v, err := item.eval(fields)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+	return vals, nil
+}
+
+type exprCall struct {
+	name string
+	fn   exprFunc
+	args []exprNode
+}
+
+func (n *exprCall) eval(fields map[string]string) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(fields)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	return n.fn.fn(args)
+}
+
+type exprMatch struct {
+	subject exprNode
+	re      *regexp.Regexp
+}
+
+func (n *exprMatch) eval(fields map[string]string) (interface{}, error) {
+	v, err := n.subject.eval(fields)
+	if err != nil {
+		return nil, err
+	}
+	return n.re.MatchString(exprString(v)), nil
+}
+
+type exprUnary struct {
+	op      string
+	operand exprNode
+}
+
+func (n *exprUnary) eval(fields map[string]string) (interface{}, error) {
+	v, err := n.operand.eval(fields)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		b, ok := v.(bool)
+		if !ok {
+			return nil, errors.New("expression: operand of ! must be bool")
+		}
+		return !b, nil
+	}
+	f, ok := exprNumber(v)
+	if !ok {
+		return nil, errors.New("expression: operand of - must be a number")
+	}
+	return -f, nil
+}
+
+type exprLogical struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *exprLogical) eval(fields map[string]string) (interface{}, error) {
+	l, err := exprBool(n.op, n.left, fields)
+	if err != nil {
+		return nil, err
+	}
+	// short circuit: true || x, false && x
+	if l == (n.op == "||") {
+		return l, nil
+	}
+	return exprBool(n.op, n.right, fields)
+}
+
+func exprBool(op string, node exprNode, fields map[string]string) (bool, error) {
+	v, err := node.eval(fields)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, errors.New("expression: operands of " + op + " must be bool")
+	}
+	return b, nil
+}
+
+type exprIn struct {
+	left, right exprNode
+}
+
+func (n *exprIn) eval(fields map[string]string) (interface{}, error) {
+	v, err := n.left.eval(fields)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(fields)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := r.([]interface{})
+	if !ok {
+		return nil, errors.New("expression: right operand of in must be a list")
+	}
+	for _, item := range list {
+		if exprEqual(v, item) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type exprBinary struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *exprBinary) eval(fields map[string]string) (interface{}, error) {
+	l, err := n.left.eval(fields)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return exprEqual(l, r), nil
+	case "!=":
+		return !exprEqual(l, r), nil
+	case "<", "<=", ">", ">=":
+		c, err := exprCompare(l, r)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	}
+
+	lf, lok := exprNumber(l)
+	rf, rok := exprNumber(r)
+	if n.op == "+" && !(lok && rok) {
+		ls, lstr := l.(string)
+		rs, rstr := r.(string)
+		if lstr && rstr {
+			return ls + rs, nil
+		}
+	}
+	if !lok || !rok {
+		return nil, errors.New("expression: operands of " + n.op + " must be numbers")
+	}
+	switch n.op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	}
+	if rf == 0 {
+		return nil, errors.New("expression: division by zero")
+	}
+	if n.op == "/" {
+		return lf / rf, nil
+	}
+	return math.Mod(lf, rf), nil
+}
+
+// exprNumber converts a value to a number, numeric strings included.
+func exprNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+// exprString converts a value to the string passed to functions.
+func exprString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+func exprEqual(l, r interface{}) bool {
+	if lf, ok := exprNumber(l); ok {
+		if rf, ok := exprNumber(r); ok {
+			return lf == rf
+		}
+	}
+	switch l := l.(type) {
+	case nil:
+		return r == nil
+	case string:
+		rs, ok := r.(string)
+		return ok && l == rs
+	case bool:
+		rb, ok := r.(bool)
+		return ok && l == rb
+	}
+	return false
+}
+
+func exprCompare(l, r interface{}) (int, error) {
+	if lf, ok := exprNumber(l); ok {
+		if rf, ok := exprNumber(r); ok {
+			switch {
+			case lf < rf:
+				return -1, nil
+			case lf > rf:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	ls, lok := l.(string)
+	rs, rok := r.(string)
+	if !lok || !rok {
+		return 0, errors.New("expression: operands must both be numbers or strings to compare")
+	}
+	return strings.Compare(ls, rs), nil
+}
@@ -0,0 +1,49 @@
+package vvalidator
+
+import (
+	"testing"
+)
+
+func TestExpr(t *testing.T) {
+	fields := map[string]string{
+		"price":    "100",
+		"discount": "20",
+		"currency": "USD",
+		"email":    "a@b.com",
+	}
+
+	e := MustCompileExpr(`discount <= price * 0.5 && (currency == "USD" || currency == "EUR")`)
+	ok, err := e.Match(fields)
+	equal(t, true, ok)
+	equal(t, nil, err)
+	ok, _ = e.Match(map[string]string{"price": "10", "discount": "20", "currency": "USD"})
+	equal(t, false, ok)
+
+	v, _ := MustCompileExpr(`price % 30 + len(currency)`).Eval(fields)
+	equal(t, 13.0, v)
+	v, _ = MustCompileExpr(`currency + "/" + 'CNY'`).Eval(fields)
+	equal(t, "USD/CNY", v)
+	v, _ = MustCompileExpr(`currency in ["EUR", "USD"] && IsEmail(email) && !IsIPv4(email)`).Eval(fields)
+	equal(t, true, v)
+	v, _ = MustCompileExpr(`missing == null && matches(currency, "^[A-Z]{3}$")`).Eval(fields)
+	equal(t, true, v)
+
+	_, err = CompileExpr(`price >`)
+	equal(t, "expression: unexpected end of expression at position 7", err.Error())
+	_, err = CompileExpr(`exec("rm")`)
+	equal(t, "expression: unknown function exec at position 0", err.Error())
+	_, err = MustCompileExpr(`price / 0`).Eval(fields)
+	equal(t, "expression: division by zero", err.Error())
+	_, err = MustCompileExpr(`currency`).Match(fields)
+	equal(t, "expression must evaluate to bool: currency", err.Error())
+
+	err = ValidateExpr(fields, "discount", "discount > price")
+	equal(t, "discount is invalid", err.Error())
+	err = ValidateExpr(map[string]string{"discount": "20", "price": "abc"}, "discount", "discount <= price * 0.5")
+	equal(t, "discount is invalid", err.Error())
+
+	_, err = CompileExpr(`matches(currency, "[")`)
+	equal(t, true, err != nil)
+	v, _ = MustCompileExpr(`matches(currency, "^" + "[A-Z]+$")`).Eval(fields)
+	equal(t, true, v)
+}
@@ -0,0 +1,107 @@
+// Package grpcvalidator converts vvalidator errors into gRPC statuses.
+//
+// The interceptors recover the Error and Errors panics of the vvalidator *p
+// functions and turn returned Error and Errors values into InvalidArgument
+// statuses with errdetails.BadRequest field violations:
+//
+//	grpc.NewServer(
+//	    grpc.UnaryInterceptor(grpcvalidator.UnaryServerInterceptor()),
+//	    grpc.StreamInterceptor(grpcvalidator.StreamServerInterceptor()),
+//	)
+package grpcvalidator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/syyongx/vvalidator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a unary server interceptor converting validation errors.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer recoverError(&err)
+		resp, err = handler(ctx, req)
+		return resp, convert(err)
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor converting validation errors.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverError(&err)
+		return convert(handler(srv, ss))
+	}
+}
+
+// Status returns the InvalidArgument status of a vvalidator Error or Errors,
+// wrapped ones included, and nil for other errors.
+func Status(err error) *status.Status {
+	var es vvalidator.Errors
+	var e vvalidator.Error
+	switch {
+	case errors.As(err, &es):
+	case errors.As(err, &e):
+		es = vvalidator.Errors{e}
+	}
+	if len(es) == 0 {
+		return nil
+	}
+
+	br := &errdetails.BadRequest{}
+	for _, e := range es {
+		field := e.Path
+		if field == "" {
+			field = e.Field
+		}
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: description(e),
+		})
+	}
+	msg := description(es[0])
+	if len(es) > 1 {
+		msg = es.Error()
+	}
+	st := status.New(codes.InvalidArgument, msg)
+	if detailed, err := st.WithDetails(br); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// description prefers the custom message of a *p function.
+func description(e vvalidator.Error) string {
+	if e.CustomMessage != "" {
+		return e.CustomMessage
+	}
+	return e.Message
+}
+
+func convert(err error) error {
+	if st := Status(err); st != nil {
+		return st.Err()
+	}
+	return err
+}
+
+// recoverError recovers a vvalidator.Error or vvalidator.Errors panic into
+// *err, other panics are propagated.
+func recoverError(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	switch e := r.(type) {
+	case vvalidator.Error:
+		*err = convert(e)
+	case vvalidator.Errors:
+		*err = convert(e)
+	default:
+		panic(r)
+	}
+}
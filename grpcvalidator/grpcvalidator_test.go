@@ -0,0 +1,136 @@
+package grpcvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/syyongx/vvalidator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// echoService is a hand written service description, as generated code would be.
+var echoService = grpc.ServiceDesc{
+	ServiceName: "test.Echo",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Echo",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(wrapperspb.StringValue)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				params := map[string]string{"uid": req.(*wrapperspb.StringValue).Value}
+				if params["uid"] == "panic" {
+					vvalidator.ValidateIntp(params, "uid", 0, 10, 400, "{label} must be a small number")
+				}
+				if params["uid"] == "panics" {
+					vvalidator.ValidateRulesp(params, map[string]string{"uid": "int", "name": "required"}, 400, "{label} is wrong")
+				}
+				if err := vvalidator.ValidateRules(params, map[string]string{"uid": "int|max:10", "name": "required"}); err != nil {
+					return nil, err
+				}
+				return req, nil
+			}
+			return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.Echo/Echo"}, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Stream",
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			in := new(wrapperspb.StringValue)
+			if err := stream.RecvMsg(in); err != nil {
+				return err
+			}
+			_, err := vvalidator.ValidateString(in.Value, "name", 1, 3)
+			return err
+		},
+	}},
+}
+
+func dial(t *testing.T) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor()),
+		grpc.StreamInterceptor(StreamServerInterceptor()),
+	)
+	srv.RegisterService(&echoService, struct{}{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func violations(err error) []string {
+	var fields []string
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				fields = append(fields, v.Field+": "+v.Description)
+			}
+		}
+	}
+	return fields
+}
+
+func TestInterceptors(t *testing.T) {
+	conn := dial(t)
+	ctx := context.Background()
+	out := new(wrapperspb.StringValue)
+
+	err := conn.Invoke(ctx, "/test.Echo/Echo", wrapperspb.String("123"), out)
+	equal(t, codes.InvalidArgument, status.Code(err))
+	equal(t, "name is required; uid is too big (maximum is 10)", status.Convert(err).Message())
+	equal(t, []string{"name: name is required", "uid: uid is too big (maximum is 10)"}, violations(err))
+
+	err = conn.Invoke(ctx, "/test.Echo/Echo", wrapperspb.String("panic"), out)
+	equal(t, codes.InvalidArgument, status.Code(err))
+	equal(t, []string{"uid: uid must be a small number"}, violations(err))
+
+	err = conn.Invoke(ctx, "/test.Echo/Echo", wrapperspb.String("panics"), out)
+	equal(t, codes.InvalidArgument, status.Code(err))
+	equal(t, []string{"name: name is wrong", "uid: uid is wrong"}, violations(err))
+
+	stream, err := conn.NewStream(ctx, &echoService.Streams[0], "/test.Echo/Stream")
+	equal(t, nil, err)
+	equal(t, nil, stream.SendMsg(wrapperspb.String("fengmoti")))
+	equal(t, nil, stream.CloseSend())
+	err = stream.RecvMsg(out)
+	equal(t, codes.InvalidArgument, status.Code(err))
+	equal(t, []string{"name: name is too long (maximum is 3 characters)"}, violations(err))
+}
+
+func TestStatus(t *testing.T) {
+	_, err := vvalidator.ValidateInt("123", "uid", 0, 10)
+	st := Status(fmt.Errorf("echo: %w", err))
+	equal(t, codes.InvalidArgument, st.Code())
+	equal(t, []string{"uid: uid is too big (maximum is 10)"}, violations(st.Err()))
+	equal(t, (*status.Status)(nil), Status(errors.New("boom")))
+	equal(t, (*status.Status)(nil), Status(nil))
+}
+
+// Expected to be equal.
+func equal(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+	}
+}
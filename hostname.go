@@ -0,0 +1,151 @@
+package vvalidator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// HostnameOptions configures ParseHostname and ValidateHostname, the zero
+// value accepts RFC 1123 host names such as "localhost" or "api.example.com".
+type HostnameOptions struct {
+	// RequireFQDN rejects names without a top-level domain, e.g. "localhost".
+	RequireFQDN bool
+	// AllowTrailingDot accepts absolute names, e.g. "example.com.".
+	AllowTrailingDot bool
+	// AllowUnderscore accepts underscores in labels, as in the names of
+	// SRV or DKIM records, e.g. "_sip._tcp.example.com".
+	AllowUnderscore bool
+	// AllowIDN accepts internationalized names, as Unicode or Punycode,
+	// e.g. "bücher.de" or "xn--bcher-kva.de".
+	AllowIDN bool
+}
+
+// ParseHostname checks an RFC 1123 host name and returns it normalized: in
+// lowercase ASCII, Unicode labels encoded as Punycode, without trailing dot.
+// Labels are letters, digits and hyphens not at either end, of at most 63
+// bytes, the name is at most 253 bytes and its last label not all digits.
+func ParseHostname(str string, opts HostnameOptions) (string, error) {
+	name := str
+	if strings.HasSuffix(name, ".") {
+		if !opts.AllowTrailingDot {
+			return "", hostnameError("trailing dot is not allowed")
+		}
+		name = name[:len(name)-1]
+	}
+	if name == "" {
+		return "", hostnameError("empty host name")
+	}
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		var ascii string
+		var err error
+		if opts.AllowUnderscore && strings.IndexByte(label, '_') >= 0 {
+			ascii, err = underscoreLabel(label)
+		} else {
+			ascii, err = asciiLabel(label, opts.AllowIDN)
+		}
+		if err != nil {
+			return "", hostnameError(err.Error())
+		}
+		labels[i] = strings.ToLower(ascii)
+	}
+	name = strings.Join(labels, ".")
+	if len(name) > 253 {
+		return "", hostnameError("too long (maximum is 253 bytes)")
+	}
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return "", hostnameError("top-level domain " + labels[len(labels)-1] + " is all digits")
+	}
+	if opts.RequireFQDN && len(labels) < 2 {
+		return "", hostnameError("host name has no top-level domain")
+	}
+	return name, nil
+}
+
+func hostnameError(reason string) error {
+	return errors.New("invalid hostname: " + reason)
+}
+
+// underscoreLabel checks an ASCII label which may have underscores.
+func underscoreLabel(label string) (string, error) {
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return "", errors.New("domain label " + label + " starts or ends with a hyphen")
+	}
+	for _, r := range label {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return "", errors.New("invalid character " + strconv.QuoteRune(r) + " in domain label")
+		}
+	}
+	if len(label) > 63 {
+		return "", errors.New("domain label " + label + " is too long (maximum is 63 bytes)")
+	}
+	return label, nil
+}
+
+// IsHostname checks if the string is an RFC 1123 host name, see ParseHostname.
+func IsHostname(str string) bool {
+	_, err := ParseHostname(str, HostnameOptions{})
+	return err == nil
+}
+
+// IsFQDN checks if the string is a fully qualified domain name, with or
+// without trailing dot, e.g. "example.com" or "example.com.".
+func IsFQDN(str string) bool {
+	_, err := ParseHostname(str, HostnameOptions{RequireFQDN: true, AllowTrailingDot: true})
+	return err == nil
+}
+
+// IsDNSLabel checks if the string is a single RFC 1123 DNS label, e.g. "api".
+func IsDNSLabel(str string) bool {
+	_, err := asciiLabel(str, false)
+	return err == nil
+}
+
+// HostnameToASCII converts an internationalized host name to its ASCII
+// form, e.g. "Bücher.de" => "xn--bcher-kva.de". Labels are lowercased, other
+// IDNA mappings such as Unicode normalization are not applied.
+func HostnameToASCII(str string) (string, error) {
+	return ParseHostname(str, HostnameOptions{AllowTrailingDot: true, AllowIDN: true})
+}
+
+// HostnameToUnicode converts the Punycode labels of a host name to Unicode,
+// e.g. "xn--bcher-kva.de" => "bücher.de".
+func HostnameToUnicode(str string) (string, error) {
+	name, err := HostnameToASCII(str)
+	if err != nil {
+		return "", err
+	}
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if strings.HasPrefix(label, "xn--") {
+			// ParseHostname checked that the label decodes.
+			labels[i], _ = punycodeDecode(label[4:])
+		}
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// ValidateHostname validate host name, see ParseHostname. The normalized
+// name is returned.
+func (v *Validator) ValidateHostname(data interface{}, key string, opts HostnameOptions) (string, error) {
+	val, err := v.checkExist(data, key, nil)
+	if err != nil {
+		return "", err
+	}
+	name, err := ParseHostname(val.(string), opts)
+	if err != nil {
+		return "", v.error(MsgRule, key, val.(string), "rule", "hostname")
+	}
+	return name, nil
+}
+
+// ValidateHostnamep validate host name with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateHostnamep(data interface{}, key string, opts HostnameOptions, code int, message string) string {
+	name, err := v.ValidateHostname(data, key, opts)
+	if err != nil {
+		v.panicError(err, code, message)
+	}
+	return name
+}
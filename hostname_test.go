@@ -0,0 +1,93 @@
+package vvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHostname(t *testing.T) {
+	label63 := strings.Repeat("a", 63)
+	for _, tt := range []struct {
+		str  string
+		opts HostnameOptions
+		want string
+		err  string
+	}{
+		{"localhost", HostnameOptions{}, "localhost", ""},
+		{"API.Example.com", HostnameOptions{}, "api.example.com", ""},
+		{"3com.com", HostnameOptions{}, "3com.com", ""},
+		{"a-b.example", HostnameOptions{}, "a-b.example", ""},
+		{label63 + ".com", HostnameOptions{}, label63 + ".com", ""},
+		{"example.com.", HostnameOptions{AllowTrailingDot: true}, "example.com", ""},
+		{"_sip._tcp.example.com", HostnameOptions{AllowUnderscore: true}, "_sip._tcp.example.com", ""},
+		{"s1._domainkey.Example.com", HostnameOptions{AllowUnderscore: true}, "s1._domainkey.example.com", ""},
+		{"Bücher.de", HostnameOptions{AllowIDN: true}, "xn--bcher-kva.de", ""},
+		{"xn--bcher-kva.de", HostnameOptions{AllowIDN: true}, "xn--bcher-kva.de", ""},
+		{"", HostnameOptions{}, "", "empty host name"},
+		{".", HostnameOptions{AllowTrailingDot: true}, "", "empty host name"},
+		{"example.com.", HostnameOptions{}, "", "trailing dot is not allowed"},
+		{"example..com", HostnameOptions{}, "", "empty domain label"},
+		{".example.com", HostnameOptions{}, "", "empty domain label"},
+		{"-example.com", HostnameOptions{}, "", "domain label -example starts or ends with a hyphen"},
+		{"example-.com", HostnameOptions{}, "", "domain label example- starts or ends with a hyphen"},
+		{"exa mple.com", HostnameOptions{}, "", "invalid character ' ' in domain label"},
+		{"_dmarc.example.com", HostnameOptions{}, "", "invalid character '_' in domain label"},
+		{"_-.example.com", HostnameOptions{AllowUnderscore: true}, "", "domain label _- starts or ends with a hyphen"},
+		{"a*_b.example.com", HostnameOptions{AllowUnderscore: true}, "", "invalid character '*' in domain label"},
+		{label63 + "a.com", HostnameOptions{}, "", "domain label " + label63 + "a is too long (maximum is 63 bytes)"},
+		{strings.Repeat(label63+".", 4) + "com", HostnameOptions{}, "", "too long (maximum is 253 bytes)"},
+		{"192.0.2.1", HostnameOptions{}, "", "top-level domain 1 is all digits"},
+		{"123", HostnameOptions{}, "", "top-level domain 123 is all digits"},
+		{"localhost", HostnameOptions{RequireFQDN: true}, "", "host name has no top-level domain"},
+		{"bücher.de", HostnameOptions{}, "", "internationalized domain label bücher is not allowed"},
+		{"xn--a.de", HostnameOptions{AllowIDN: true}, "", "invalid punycode domain label xn--a"},
+	} {
+		name, err := ParseHostname(tt.str, tt.opts)
+		if tt.err == "" {
+			equal(t, nil, err)
+			equal(t, tt.want, name)
+		} else if err == nil {
+			t.Errorf("%s: expected error %s", tt.str, tt.err)
+		} else {
+			equal(t, "invalid hostname: "+tt.err, err.Error())
+		}
+	}
+}
+
+func TestIsHostname(t *testing.T) {
+	equal(t, true, IsHostname("db-1"))
+	equal(t, false, IsHostname("db_1"))
+	equal(t, false, IsHostname("https://example.com"))
+	equal(t, true, IsFQDN("example.com"))
+	equal(t, true, IsFQDN("example.com."))
+	equal(t, false, IsFQDN("localhost"))
+	equal(t, true, IsDNSLabel("api"))
+	equal(t, false, IsDNSLabel("api.example"))
+	equal(t, false, IsDNSLabel(strings.Repeat("a", 64)))
+}
+
+func TestHostnameIDNA(t *testing.T) {
+	ascii, err := HostnameToASCII("Mañana.Example.")
+	equal(t, nil, err)
+	equal(t, "xn--maana-pta.example", ascii)
+	unicode, err := HostnameToUnicode("xn--maana-pta.example")
+	equal(t, nil, err)
+	equal(t, "mañana.example", unicode)
+	unicode, err = HostnameToUnicode("bücher.de")
+	equal(t, nil, err)
+	equal(t, "bücher.de", unicode)
+	_, err = HostnameToUnicode("xn--a.de")
+	equal(t, "invalid hostname: invalid punycode domain label xn--a", err.Error())
+}
+
+func TestValidateHostname(t *testing.T) {
+	params := map[string]string{"host": "Bücher.de", "bad": "bad_host"}
+	opts := HostnameOptions{AllowIDN: true}
+	name, err := ValidateHostname(params, "host", opts)
+	equal(t, nil, err)
+	equal(t, "xn--bcher-kva.de", name)
+	_, err = ValidateHostname(params, "bad", opts)
+	equal(t, "bad must be a valid hostname", err.Error())
+	_, err = ValidateHostname(params, "missing", opts)
+	equal(t, "missing is required", err.Error())
+}
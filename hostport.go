@@ -0,0 +1,232 @@
+package vvalidator
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// HostPortOptions configures ParseHostPort, ParseEndpoints and their
+// Validate functions, the zero value requires a port.
+type HostPortOptions struct {
+	// DefaultPort is the port of endpoints without one, 0 requires a port.
+	DefaultPort int
+	// Ports are the allowed port ranges, empty means any.
+	Ports []PortRange
+	// Hostname are the options of the host names, IP addresses are always
+	// accepted, IPv6 ones in brackets, e.g. "[2001:db8::1]:443".
+	Hostname HostnameOptions
+}
+
+// HostPort is a host, a normalized host name or an IP address, and a port.
+type HostPort struct {
+	Host string
+	Port int
+}
+
+// String returns the endpoint as "host:port", or "[host]:port" for IPv6.
+func (hp HostPort) String() string {
+	return net.JoinHostPort(hp.Host, strconv.Itoa(hp.Port))
+}
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	From, To int
+}
+
+// Contains reports whether the port is in the range.
+func (r PortRange) Contains(port int) bool {
+	return port >= r.From && port <= r.To
+}
+
+// String returns the range as "from-to", or "port" for a single port.
+func (r PortRange) String() string {
+	if r.From == r.To {
+		return strconv.Itoa(r.From)
+	}
+	return strconv.Itoa(r.From) + "-" + strconv.Itoa(r.To)
+}
+
+// ParsePortRange parses a port range such as "8000-8100", or a single port.
+func ParsePortRange(str string) (PortRange, error) {
+	from, to := str, str
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		from, to = str[:i], str[i+1:]
+	}
+	r := PortRange{From: parsePort(from), To: parsePort(to)}
+	if r.From == 0 || r.To == 0 {
+		return PortRange{}, errors.New("invalid port range " + str)
+	}
+	if r.From > r.To {
+		return PortRange{}, errors.New("invalid port range " + str + ": start is after its end")
+	}
+	return r, nil
+}
+
+// IsPortRange checks if the string is a port range, see ParsePortRange.
+func IsPortRange(str string) bool {
+	_, err := ParsePortRange(str)
+	return err == nil
+}
+
+// parsePort parses a port of 1 to 65535 in decimal digits, 0 if invalid.
+func parsePort(str string) int {
+	if str == "" || len(str) > 5 || strings.Trim(str, "0123456789") != "" {
+		return 0
+	}
+	n, _ := strconv.Atoi(str)
+	if n > 65535 {
+		return 0
+	}
+	return n
+}
+
+// ParseHostPort parses an endpoint such as "example.com:443", "192.0.2.1:80"
+// or "[2001:db8::1]:443" and checks it against the options. Host names are
+// checked and normalized with ParseHostname.
+func ParseHostPort(str string, opts HostPortOptions) (HostPort, error) {
+	host, portStr, err := splitHostPort(str)
+	if err != nil {
+		return HostPort{}, hostPortError(str, err.Error())
+	}
+	port := opts.DefaultPort
+	if portStr != "" {
+		if port = parsePort(portStr); port == 0 {
+			return HostPort{}, hostPortError(str, "invalid port "+portStr)
+		}
+	} else if port == 0 {
+		return HostPort{}, hostPortError(str, "missing port")
+	}
+	if len(opts.Ports) > 0 {
+		allowed := false
+		for _, r := range opts.Ports {
+			if r.Contains(port) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return HostPort{}, hostPortError(str, "port "+strconv.Itoa(port)+" is not allowed")
+		}
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return HostPort{Host: addr.String(), Port: port}, nil
+	}
+	name, err := ParseHostname(host, opts.Hostname)
+	if err != nil {
+		return HostPort{}, hostPortError(str, err.Error())
+	}
+	return HostPort{Host: name, Port: port}, nil
+}
+
+// splitHostPort splits "host:port", "[host]:port", "host" or "[host]", the
+// port is empty if missing. Bracketed hosts must be IPv6 addresses.
+func splitHostPort(str string) (host, port string, err error) {
+	if strings.HasPrefix(str, "[") {
+		end := strings.IndexByte(str, ']')
+		if end < 0 {
+			return "", "", errors.New("missing ]")
+		}
+		host, rest := str[1:end], str[end+1:]
+		if addr, err := netip.ParseAddr(host); err != nil || !addr.Is6() {
+			return "", "", errors.New("invalid IPv6 address " + host)
+		} else if addr.Zone() != "" {
+			return "", "", errors.New("zone is not allowed in " + host)
+		}
+		if rest == "" {
+			return host, "", nil
+		}
+		if rest[0] != ':' || rest == ":" {
+			return "", "", errors.New("invalid port " + rest)
+		}
+		return host, rest[1:], nil
+	}
+	switch strings.Count(str, ":") {
+	case 0:
+		return str, "", nil
+	case 1:
+		i := strings.IndexByte(str, ':')
+		if i == len(str)-1 {
+			return "", "", errors.New("missing port")
+		}
+		return str[:i], str[i+1:], nil
+	}
+	return "", "", errors.New("IPv6 address must be in brackets")
+}
+
+func hostPortError(str, reason string) error {
+	return errors.New("invalid host:port " + str + ": " + reason)
+}
+
+// ParseEndpoints parses a comma-separated list of endpoints, e.g.
+// "db1:5432, db2:5432", see ParseHostPort.
+func ParseEndpoints(str string, opts HostPortOptions) ([]HostPort, error) {
+	var endpoints []HostPort
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return nil, errors.New("invalid endpoints " + str + ": empty endpoint")
+		}
+		hp, err := ParseHostPort(item, opts)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, hp)
+	}
+	return endpoints, nil
+}
+
+// IsHostPort checks if the string is "host:port", see ParseHostPort.
+func IsHostPort(str string) bool {
+	_, err := ParseHostPort(str, HostPortOptions{})
+	return err == nil
+}
+
+// ValidateHostPort validate host and port, see ParseHostPort.
+func (v *Validator) ValidateHostPort(data interface{}, key string, opts HostPortOptions) (string, int, error) {
+	val, err := v.checkExist(data, key, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	hp, err := ParseHostPort(val.(string), opts)
+	if err != nil {
+		return "", 0, v.error(MsgRule, key, val.(string), "rule", "hostport")
+	}
+	return hp.Host, hp.Port, nil
+}
+
+// ValidateHostPortp validate host and port with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateHostPortp(data interface{}, key string, opts HostPortOptions, code int, message string) (string, int) {
+	host, port, err := v.ValidateHostPort(data, key, opts)
+	if err != nil {
+		v.panicError(err, code, message)
+	}
+	return host, port
+}
+
+// ValidateEndpoints validate comma-separated endpoints, see ParseEndpoints.
+func (v *Validator) ValidateEndpoints(data interface{}, key string, opts HostPortOptions) ([]HostPort, error) {
+	val, err := v.checkExist(data, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := ParseEndpoints(val.(string), opts)
+	if err != nil {
+		return nil, v.error(MsgRule, key, val.(string), "rule", "endpoints")
+	}
+	return endpoints, nil
+}
+
+// ValidateEndpointsp validate comma-separated endpoints with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateEndpointsp(data interface{}, key string, opts HostPortOptions, code int, message string) []HostPort {
+	endpoints, err := v.ValidateEndpoints(data, key, opts)
+	if err != nil {
+		v.panicError(err, code, message)
+	}
+	return endpoints
+}
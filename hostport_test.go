@@ -0,0 +1,103 @@
+package vvalidator
+
+import (
+	"testing"
+)
+
+func TestParsePortRange(t *testing.T) {
+	r, err := ParsePortRange("8000-8100")
+	equal(t, nil, err)
+	equal(t, PortRange{From: 8000, To: 8100}, r)
+	equal(t, "8000-8100", r.String())
+	equal(t, true, r.Contains(8000))
+	equal(t, true, r.Contains(8100))
+	equal(t, false, r.Contains(8101))
+	r, err = ParsePortRange("443")
+	equal(t, nil, err)
+	equal(t, "443", r.String())
+
+	_, err = ParsePortRange("8100-8000")
+	equal(t, "invalid port range 8100-8000: start is after its end", err.Error())
+	for _, str := range []string{"", "-", "0-80", "80-65536", "+80", "80-", "a-b", "1-2-3"} {
+		if IsPortRange(str) {
+			t.Errorf("%q: expected an invalid port range", str)
+		}
+	}
+}
+
+func TestParseHostPort(t *testing.T) {
+	for _, tt := range []struct {
+		str  string
+		opts HostPortOptions
+		want HostPort
+		err  string
+	}{
+		{"Example.com:443", HostPortOptions{}, HostPort{"example.com", 443}, ""},
+		{"localhost:8080", HostPortOptions{}, HostPort{"localhost", 8080}, ""},
+		{"192.0.2.1:80", HostPortOptions{}, HostPort{"192.0.2.1", 80}, ""},
+		{"[2001:DB8::1]:443", HostPortOptions{}, HostPort{"2001:db8::1", 443}, ""},
+		{"example.com", HostPortOptions{DefaultPort: 80}, HostPort{"example.com", 80}, ""},
+		{"[::1]", HostPortOptions{DefaultPort: 80}, HostPort{"::1", 80}, ""},
+		{"bücher.de:443", HostPortOptions{Hostname: HostnameOptions{AllowIDN: true}}, HostPort{"xn--bcher-kva.de", 443}, ""},
+		{"example.com:8050", HostPortOptions{Ports: []PortRange{{8000, 8100}}}, HostPort{"example.com", 8050}, ""},
+		{"example.com", HostPortOptions{}, HostPort{}, "missing port"},
+		{"example.com:", HostPortOptions{}, HostPort{}, "missing port"},
+		{"example.com:0", HostPortOptions{}, HostPort{}, "invalid port 0"},
+		{"example.com:65536", HostPortOptions{}, HostPort{}, "invalid port 65536"},
+		{"example.com:+80", HostPortOptions{}, HostPort{}, "invalid port +80"},
+		{"example.com:http", HostPortOptions{}, HostPort{}, "invalid port http"},
+		{"2001:db8::1", HostPortOptions{}, HostPort{}, "IPv6 address must be in brackets"},
+		{"[2001:db8::1]443", HostPortOptions{}, HostPort{}, "invalid port 443"},
+		{"[2001:db8::1]:", HostPortOptions{}, HostPort{}, "invalid port :"},
+		{"[2001:db8::1", HostPortOptions{}, HostPort{}, "missing ]"},
+		{"[192.0.2.1]:80", HostPortOptions{}, HostPort{}, "invalid IPv6 address 192.0.2.1"},
+		{"[fe80::1%eth0]:80", HostPortOptions{}, HostPort{}, "zone is not allowed in fe80::1%eth0"},
+		{":80", HostPortOptions{}, HostPort{}, "invalid hostname: empty host name"},
+		{"exa_mple.com:80", HostPortOptions{}, HostPort{}, "invalid hostname: invalid character '_' in domain label"},
+		{"example.com:9000", HostPortOptions{Ports: []PortRange{{8000, 8100}}}, HostPort{}, "port 9000 is not allowed"},
+		{"example.com", HostPortOptions{DefaultPort: 80, Ports: []PortRange{{443, 443}}}, HostPort{}, "port 80 is not allowed"},
+	} {
+		hp, err := ParseHostPort(tt.str, tt.opts)
+		if tt.err == "" {
+			equal(t, nil, err)
+			equal(t, tt.want, hp)
+		} else if err == nil {
+			t.Errorf("%s: expected error %s", tt.str, tt.err)
+		} else {
+			equal(t, "invalid host:port "+tt.str+": "+tt.err, err.Error())
+		}
+	}
+
+	equal(t, "[2001:db8::1]:443", HostPort{"2001:db8::1", 443}.String())
+	equal(t, true, IsHostPort("db:5432"))
+	equal(t, false, IsHostPort("db"))
+}
+
+func TestParseEndpoints(t *testing.T) {
+	endpoints, err := ParseEndpoints("db1:5432, [2001:db8::1]:5433,db3", HostPortOptions{DefaultPort: 5432})
+	equal(t, nil, err)
+	equal(t, []HostPort{{"db1", 5432}, {"2001:db8::1", 5433}, {"db3", 5432}}, endpoints)
+
+	_, err = ParseEndpoints("db1:5432,,db2:5432", HostPortOptions{})
+	equal(t, "invalid endpoints db1:5432,,db2:5432: empty endpoint", err.Error())
+	_, err = ParseEndpoints("db1:5432, db2", HostPortOptions{})
+	equal(t, "invalid host:port db2: missing port", err.Error())
+}
+
+func TestValidateHostPort(t *testing.T) {
+	params := map[string]string{"listen": "0.0.0.0:8080", "peers": "a:1,b:2", "bad": "a:b:c"}
+	host, port, err := ValidateHostPort(params, "listen", HostPortOptions{})
+	equal(t, nil, err)
+	equal(t, "0.0.0.0", host)
+	equal(t, 8080, port)
+	_, _, err = ValidateHostPort(params, "bad", HostPortOptions{})
+	equal(t, "bad must be a valid hostport", err.Error())
+	_, _, err = ValidateHostPort(params, "missing", HostPortOptions{})
+	equal(t, "missing is required", err.Error())
+
+	endpoints, err := ValidateEndpoints(params, "peers", HostPortOptions{})
+	equal(t, nil, err)
+	equal(t, 2, len(endpoints))
+	_, err = ValidateEndpoints(params, "bad", HostPortOptions{})
+	equal(t, "bad must be a valid endpoints", err.Error())
+}
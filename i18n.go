@@ -0,0 +1,221 @@
+package vvalidator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Catalog holds the message templates and field labels of a locale.
+type Catalog struct {
+	// Messages are the message templates by message id.
+	Messages map[string]string
+	// Labels are the names shown for fields by field key, e.g. "uid" => "用户ID".
+	Labels map[string]string
+}
+
+// Catalogs are the built-in catalogs by locale, copied into every new validator.
+var Catalogs = map[string]Catalog{
+	"en":    {Messages: DefaultMessages},
+	"zh-CN": {Messages: zhCNMessages},
+}
+
+var zhCNMessages = map[string]string{
+	MsgRequired:   "{field} 是必填项",
+	MsgEmpty:      "{field} 不能为空",
+	MsgDataType:   "数据类型无效，必须是 {types}",
+	MsgType:       "类型无效，必须是 {types}",
+	MsgFieldType:  "{field} 类型无效，必须是 {types}",
+	MsgInt:        "{field} 必须是整数",
+	MsgInt64:      "{field} 必须是有效的整数",
+	MsgFloat:      "{field} 必须是有效的浮点数",
+	MsgMin:        "{field} 太小（最小值为 {min}）",
+	MsgMax:        "{field} 太大（最大值为 {max}）",
+	MsgMinLength:  "{field} 太短（最少 {min} 个字符）",
+	MsgMaxLength:  "{field} 太长（最多 {max} 个字符）",
+	MsgMinItems:   "{field} 太短（最少 {min} 个元素）",
+	MsgMaxItems:   "{field} 太长（最多 {max} 个元素）",
+	MsgPattern:    "{field} 格式不正确",
+	MsgInvalid:    "{field} 无效",
+	MsgRule:       "{field} 必须是有效的 {rule}",
+	MsgNotAllowed: "不允许 {field}",
+}
+
+func (c Catalog) clone() *Catalog {
+	cc := &Catalog{
+		Messages: make(map[string]string, len(c.Messages)),
+		Labels:   make(map[string]string, len(c.Labels)),
+	}
+	cc.merge(c)
+	return cc
+}
+
+func (c *Catalog) merge(other Catalog) {
+	for id, tpl := range other.Messages {
+		c.Messages[id] = tpl
+	}
+	for key, label := range other.Labels {
+		c.Labels[key] = label
+	}
+}
+
+// AddCatalog adds the messages and labels of c to the catalog of locale,
+// creating the locale if needed.
+func (v *Validator) AddCatalog(locale string, c Catalog) {
+	v.reg.mu.Lock()
+	defer v.reg.mu.Unlock()
+	if cur, ok := v.reg.catalogs[locale]; ok {
+		cur.merge(c)
+		return
+	}
+	v.reg.catalogs[locale] = c.clone()
+}
+
+// Locales returns the sorted locales of the validator catalogs.
+func (v *Validator) Locales() []string {
+	v.reg.mu.RLock()
+	defer v.reg.mu.RUnlock()
+	locales := make([]string, 0, len(v.reg.catalogs))
+	for locale := range v.reg.catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// WithLocale returns a copy of the validator using the catalog that best
+// matches locale. The copy has its own Messages, Labels and Sensitive maps,
+// it shares the rules and catalogs of v.
+func (v *Validator) WithLocale(locale string) *Validator {
+	c := *v
+	c.Messages = make(map[string]string, len(v.Messages))
+	for id, msg := range v.Messages {
+		c.Messages[id] = msg
+	}
+	c.Labels = make(map[string]string, len(v.Labels))
+	for key, label := range v.Labels {
+		c.Labels[key] = label
+	}
+	c.Sensitive = make(map[string]bool, len(v.Sensitive))
+	for key, sensitive := range v.Sensitive {
+		c.Sensitive[key] = sensitive
+	}
+	if match := MatchLocale(locale, v.Locales()); match != "" {
+		c.Locale = match
+	}
+	return &c
+}
+
+// WithAcceptLanguage returns a copy of the validator using the catalog that
+// best matches an Accept-Language header such as "zh-CN,zh;q=0.9,en;q=0.8".
+func (v *Validator) WithAcceptLanguage(header string) *Validator {
+	return v.WithLocale(header)
+}
+
+// WithLocale returns a copy of the default validator using locale.
+func WithLocale(locale string) *Validator {
+	return std.WithLocale(locale)
+}
+
+// WithAcceptLanguage returns a copy of the default validator using the
+// locale of an Accept-Language header.
+func WithAcceptLanguage(header string) *Validator {
+	return std.WithAcceptLanguage(header)
+}
+
+// MatchLocale returns the available locale that best matches the language
+// ranges of an Accept-Language header (a single locale is a header too),
+// or "" if none matches. Ranges match exactly first, then by language,
+// so "zh", "zh-Hans" and "zh-TW" all match "zh-CN" when there is no better one.
+func MatchLocale(header string, available []string) string {
+	type langRange struct {
+		tag string
+		q   float64
+	}
+	var ranges []langRange
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if f, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q > 0 {
+			ranges = append(ranges, langRange{tag: strings.Replace(tag, "_", "-", -1), q: q})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	for _, r := range ranges {
+		for _, locale := range available {
+			if strings.EqualFold(r.tag, locale) {
+				return locale
+			}
+		}
+		for _, locale := range available {
+			if strings.EqualFold(localeLanguage(r.tag), localeLanguage(locale)) {
+				return locale
+			}
+		}
+	}
+	return ""
+}
+
+// localeLanguage returns the language subtag of a locale, "zh" for "zh-CN".
+func localeLanguage(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+// catalogMessage returns the template of id for the field key in the
+// validator locale, falling back to English.
+func (v *Validator) catalogMessage(id, key string) (string, bool) {
+	v.reg.mu.RLock()
+	defer v.reg.mu.RUnlock()
+	for _, locale := range []string{v.locale(), "en"} {
+		c, ok := v.reg.catalogs[locale]
+		if !ok {
+			continue
+		}
+		if tpl, ok := c.Messages[key+"."+id]; ok {
+			return tpl, true
+		}
+		if tpl, ok := c.Messages[id]; ok {
+			return tpl, true
+		}
+	}
+	return "", false
+}
+
+// label returns the label of a field key in the validator locale, then
+// from Labels, or the key itself.
+func (v *Validator) label(key string) string {
+	v.reg.mu.RLock()
+	defer v.reg.mu.RUnlock()
+	if c, ok := v.reg.catalogs[v.locale()]; ok {
+		if label, ok := c.Labels[key]; ok {
+			return label
+		}
+	}
+	if label, ok := v.Labels[key]; ok {
+		return label
+	}
+	return key
+}
+
+func (v *Validator) locale() string {
+	if v.Locale == "" {
+		return "en"
+	}
+	return v.Locale
+}
@@ -0,0 +1,43 @@
+package vvalidator
+
+import (
+	"testing"
+)
+
+func TestI18n(t *testing.T) {
+	params := map[string]string{
+		"uid": "123",
+	}
+
+	equal(t, "zh-CN", MatchLocale("zh-CN,zh;q=0.9,en;q=0.8", []string{"en", "zh-CN"}))
+	equal(t, "en", MatchLocale("fr;q=0.9,en-US;q=0.5", []string{"en", "zh-CN"}))
+	equal(t, "zh-CN", MatchLocale("zh-TW", []string{"en", "zh-CN"}))
+	equal(t, "", MatchLocale("fr", []string{"en", "zh-CN"}))
+
+	v := New()
+	v.AddCatalog("zh-CN", Catalog{Labels: map[string]string{"uid": "用户ID"}})
+	_, err := v.WithAcceptLanguage("zh-CN,zh;q=0.9").ValidateInt(params, "uid", 0, 10)
+	equal(t, "用户ID 太大（最大值为 10）", err.Error())
+	_, err = v.WithLocale("zh").ValidateString(params, "nickname", 0, 10)
+	equal(t, "nickname 是必填项", err.Error())
+	_, err = v.WithLocale("fr").ValidateInt(params, "uid", 0, 10)
+	equal(t, "uid is too big (maximum is 10)", err.Error())
+
+	v.AddCatalog("fr", Catalog{Messages: map[string]string{MsgMax: "{field} est trop grand (maximum {max})"}})
+	_, err = v.WithLocale("fr-FR").ValidateInt(params, "uid", 0, 10)
+	equal(t, "uid est trop grand (maximum 10)", err.Error())
+	_, err = v.WithLocale("fr").ValidateInt(params, "uid", 200, 300)
+	equal(t, "uid is too small (minimum is 200)", err.Error())
+
+	// the copy has its own maps
+	v.Labels["uid"] = "UID"
+	c := v.WithLocale("en")
+	c.Labels["uid"] = "User"
+	c.Messages[MsgMax] = "{field} > {max}"
+	c.Sensitive["uid"] = true
+	equal(t, map[string]string{"uid": "UID"}, v.Labels)
+	equal(t, 0, len(v.Messages))
+	equal(t, 0, len(v.Sensitive))
+	_, err = c.ValidateInt(params, "uid", 0, 10)
+	equal(t, "User > 10", err.Error())
+}
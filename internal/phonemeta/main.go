@@ -0,0 +1,279 @@
+// Command phonemeta generates phone_metadata.txt, the phone number metadata
+// of ParsePhone, from the PhoneNumberMetadata.xml of libphonenumber. Run it
+// from the module root with go generate:
+//
+//	go generate -run phonemeta .
+//
+// Usage:
+//
+//	phonemeta [-src url or file] [-o file]
+//
+// The source defaults to the metadata of the libphonenumber master branch.
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syyongx/vvalidator"
+)
+
+const defaultSource = "https://raw.githubusercontent.com/google/libphonenumber/master/resources/PhoneNumberMetadata.xml"
+
+func main() {
+	src := flag.String("src", defaultSource, "URL or file name of PhoneNumberMetadata.xml")
+	output := flag.String("o", "phone_metadata.txt", "output file name")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: phonemeta [-src url or file] [-o file]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*src, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "phonemeta:", err)
+		os.Exit(1)
+	}
+}
+
+func run(src, output string) error {
+	data, err := read(src)
+	if err != nil {
+		return err
+	}
+	out, err := generate(data, time.Now())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, out, 0644)
+}
+
+func read(src string) ([]byte, error) {
+	if !strings.HasPrefix(src, "https://") && !strings.HasPrefix(src, "http://") {
+		return os.ReadFile(src)
+	}
+	resp, err := http.Get(src)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("get " + src + ": " + resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// territory is a territory element of PhoneNumberMetadata.xml, the number
+// types are its child elements, e.g. fixedLine or tollFree.
+type territory struct {
+	ID              string       `xml:"id,attr"`
+	CountryCode     string       `xml:"countryCode,attr"`
+	Main            bool         `xml:"mainCountryForCode,attr"`
+	Intl            string       `xml:"internationalPrefix,attr"`
+	National        string       `xml:"nationalPrefix,attr"`
+	NationalParsing string       `xml:"nationalPrefixForParsing,attr"`
+	Transform       string       `xml:"nationalPrefixTransformRule,attr"`
+	Leading         string       `xml:"leadingDigits,attr"`
+	Types           []numberType `xml:",any"`
+}
+
+type numberType struct {
+	XMLName xml.Name
+	Pattern string `xml:"nationalNumberPattern"`
+	Lengths *struct {
+		National string `xml:"national,attr"`
+	} `xml:"possibleLengths"`
+}
+
+func (t *territory) numberType(name string) *numberType {
+	for i := range t.Types {
+		if t.Types[i].XMLName.Local == name {
+			return &t.Types[i]
+		}
+	}
+	return nil
+}
+
+var (
+	whitespace = regexp.MustCompile(`\s+`)
+	group      = regexp.MustCompile(`\$(\d+)`)
+)
+
+const header = `# Phone number metadata, one region per line, derived from the metadata of
+# libphonenumber (https://github.com/google/libphonenumber, Apache License
+# 2.0), snapshot of %s. Regenerate it with go generate, see
+# internal/phonemeta, or load a current version with LoadPhoneMetadata.
+#
+# Tab-separated columns, "-" means none:
+#   region              ISO 3166-1 alpha-2 code, 001 for non-geographic numbers
+#   code                country calling code
+#   main                "main" for the main region of a shared calling code
+#   international       international prefix pattern, e.g. 011 in the US
+#   national            national prefix, e.g. 0 in the UK
+#   national parsing    pattern of the national prefix to strip when parsing
+#   transform           replacement of the national prefix, e.g. the area code
+#   leading             leading digits pattern of the region numbers
+#   lengths             possible lengths of the national significant numbers
+#   general             pattern of the national significant numbers
+#   fixed               pattern of the fixed-line numbers
+#   mobile              pattern of the mobile numbers
+`
+
+// generate converts PhoneNumberMetadata.xml to the format of
+// phone_metadata.txt, sorted by country calling code, main region first.
+func generate(data []byte, date time.Time) ([]byte, error) {
+	var md struct {
+		Territories []territory `xml:"territories>territory"`
+	}
+	if err := xml.Unmarshal(data, &md); err != nil {
+		return nil, err
+	}
+	if len(md.Territories) == 0 {
+		return nil, errors.New("no territory found")
+	}
+	type line struct {
+		t    *territory
+		code int
+		text string
+	}
+	lines := make([]line, 0, len(md.Territories))
+	for i := range md.Territories {
+		t := &md.Territories[i]
+		code, err := strconv.Atoi(t.CountryCode)
+		if err != nil {
+			return nil, errors.New(t.ID + ": invalid country code " + t.CountryCode)
+		}
+		text, err := format(t)
+		if err != nil {
+			return nil, errors.New(t.ID + ": " + err.Error())
+		}
+		lines = append(lines, line{t, code, text})
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		if a.code != b.code {
+			return a.code < b.code
+		}
+		if a.t.Main != b.t.Main {
+			return a.t.Main
+		}
+		return a.t.ID < b.t.ID
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, date.Format("2006-01"))
+	for _, l := range lines {
+		buf.WriteString(l.text)
+		buf.WriteByte('\n')
+	}
+	// Check the patterns, Go and libphonenumber regular expressions differ.
+	if err := vvalidator.LoadPhoneMetadata(bytes.NewReader(buf.Bytes())); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// format returns the line of a territory. The national prefix is stripped
+// when parsing if no other pattern is given, as in libphonenumber, and the
+// possible lengths are those of all number types.
+func format(t *territory) (string, error) {
+	general := t.numberType("generalDesc")
+	if general == nil || general.Pattern == "" {
+		return "", errors.New("missing generalDesc")
+	}
+	parsing := t.NationalParsing
+	if parsing == "" {
+		parsing = t.National
+	}
+	mainRegion := ""
+	if t.Main {
+		mainRegion = "main"
+	}
+	lengths, err := possibleLengths(t)
+	if err != nil {
+		return "", err
+	}
+	fields := []string{
+		t.ID,
+		t.CountryCode,
+		mainRegion,
+		t.Intl,
+		t.National,
+		parsing,
+		group.ReplaceAllString(t.Transform, "$${$1}"),
+		t.Leading,
+		lengths,
+		general.Pattern,
+		pattern(t.numberType("fixedLine")),
+		pattern(t.numberType("mobile")),
+	}
+	for i, f := range fields {
+		if f = whitespace.ReplaceAllString(f, ""); f == "" {
+			f = "-"
+		}
+		fields[i] = f
+	}
+	return strings.Join(fields, "\t"), nil
+}
+
+func pattern(nt *numberType) string {
+	if nt == nil {
+		return ""
+	}
+	return nt.Pattern
+}
+
+// possibleLengths returns the sorted national lengths of the number types,
+// given as lists of lengths and ranges, e.g. "[4-6],8". Numbers that can't
+// be dialled internationally are left out.
+func possibleLengths(t *territory) (string, error) {
+	seen := make(map[int]bool)
+	for _, nt := range t.Types {
+		if nt.Lengths == nil || nt.XMLName.Local == "noInternationalDialling" {
+			continue
+		}
+		for _, l := range strings.Split(nt.Lengths.National, ",") {
+			l = strings.TrimSpace(l)
+			if l == "" || l == "-1" {
+				continue
+			}
+			from, to := l, l
+			if strings.HasPrefix(l, "[") && strings.HasSuffix(l, "]") {
+				if i := strings.IndexByte(l, '-'); i > 0 {
+					from, to = l[1:i], l[i+1:len(l)-1]
+				}
+			}
+			min, err1 := strconv.Atoi(from)
+			max, err2 := strconv.Atoi(to)
+			if err1 != nil || err2 != nil || min <= 0 || max < min {
+				return "", errors.New("invalid possible lengths " + nt.Lengths.National)
+			}
+			for n := min; n <= max; n++ {
+				seen[n] = true
+			}
+		}
+	}
+	if len(seen) == 0 {
+		return "", errors.New("missing possible lengths")
+	}
+	lengths := make([]int, 0, len(seen))
+	for n := range seen {
+		lengths = append(lengths, n)
+	}
+	sort.Ints(lengths)
+	strs := make([]string, len(lengths))
+	for i, n := range lengths {
+		strs[i] = strconv.Itoa(n)
+	}
+	return strings.Join(strs, ","), nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/syyongx/vvalidator"
+)
+
+const sample = `<?xml version="1.0" encoding="UTF-8"?>
+<phoneNumberMetadata>
+  <territories>
+    <!-- Antigua & Barbuda -->
+    <territory id="AG" countryCode="1" internationalPrefix="011" leadingDigits="268"
+               nationalPrefix="1" nationalPrefixForParsing="([457]\d{6})$|1"
+               nationalPrefixTransformRule="268$1">
+      <generalDesc>
+        <nationalNumberPattern>
+          (?:
+            268|
+            [58]\d\d|
+            900
+          )\d{7}
+        </nationalNumberPattern>
+      </generalDesc>
+      <fixedLine>
+        <possibleLengths national="10" localOnly="7"/>
+        <nationalNumberPattern>268(?:4(?:6[0-38]|84)|56[0-2])\d{4}</nationalNumberPattern>
+      </fixedLine>
+      <mobile>
+        <possibleLengths national="10" localOnly="7"/>
+        <nationalNumberPattern>268(?:464|7(?:1[3-9]|2\d|3[246]|64|[78][0-689]))\d{4}</nationalNumberPattern>
+      </mobile>
+    </territory>
+    <!-- United States -->
+    <territory id="US" countryCode="1" internationalPrefix="011" mainCountryForCode="true"
+               nationalPrefix="1">
+      <generalDesc>
+        <nationalNumberPattern>[2-9]\d{9}</nationalNumberPattern>
+      </generalDesc>
+      <fixedLine>
+        <possibleLengths national="10" localOnly="7"/>
+        <nationalNumberPattern>[2-9]\d{9}</nationalNumberPattern>
+      </fixedLine>
+      <mobile>
+        <possibleLengths national="10" localOnly="7"/>
+        <nationalNumberPattern>[2-9]\d{9}</nationalNumberPattern>
+      </mobile>
+    </territory>
+    <!-- Germany -->
+    <territory id="DE" countryCode="49" internationalPrefix="00" nationalPrefix="0">
+      <generalDesc>
+        <nationalNumberPattern>[1-9]\d{3,14}</nationalNumberPattern>
+      </generalDesc>
+      <noInternationalDialling>
+        <possibleLengths national="3"/>
+        <nationalNumberPattern>118\d</nationalNumberPattern>
+      </noInternationalDialling>
+      <fixedLine>
+        <possibleLengths national="[5-7],15"/>
+        <nationalNumberPattern>[2-9]\d{4,14}</nationalNumberPattern>
+      </fixedLine>
+      <mobile>
+        <possibleLengths national="[10-11]"/>
+        <nationalNumberPattern>1[5-7]\d{8,9}</nationalNumberPattern>
+      </mobile>
+    </territory>
+    <!-- International Freephone -->
+    <territory id="001" countryCode="800">
+      <generalDesc>
+        <nationalNumberPattern>\d{8}</nationalNumberPattern>
+      </generalDesc>
+      <tollFree>
+        <possibleLengths national="8"/>
+        <nationalNumberPattern>\d{8}</nationalNumberPattern>
+      </tollFree>
+    </territory>
+  </territories>
+</phoneNumberMetadata>
+`
+
+func TestGenerate(t *testing.T) {
+	out, err := generate([]byte(sample), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(out)
+	if !strings.Contains(text, "snapshot of 2026-10.") {
+		t.Errorf("missing snapshot date in header")
+	}
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSpace(text), "\n") {
+		if !strings.HasPrefix(l, "#") {
+			lines = append(lines, l)
+		}
+	}
+	want := []string{
+		"US\t1\tmain\t011\t1\t1\t-\t-\t10\t[2-9]\\d{9}\t[2-9]\\d{9}\t[2-9]\\d{9}",
+		"AG\t1\t-\t011\t1\t([457]\\d{6})$|1\t268${1}\t268\t10\t(?:268|[58]\\d\\d|900)\\d{7}\t268(?:4(?:6[0-38]|84)|56[0-2])\\d{4}\t268(?:464|7(?:1[3-9]|2\\d|3[246]|64|[78][0-689]))\\d{4}",
+		"DE\t49\t-\t00\t0\t0\t-\t-\t5,6,7,10,11,15\t[1-9]\\d{3,14}\t[2-9]\\d{4,14}\t1[5-7]\\d{8,9}",
+		"001\t800\t-\t-\t-\t-\t-\t-\t8\t\\d{8}\t-\t-",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), text)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d:\ngot  %q\nwant %q", i, lines[i], want[i])
+		}
+	}
+
+	// generate loads the metadata it writes.
+	p, err := vvalidator.ParsePhone("0151 23456789", "DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.E164() != "+4915123456789" || p.Type != vvalidator.PhoneMobile {
+		t.Errorf("got %s of type %s", p.E164(), p.Type)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, tt := range []struct {
+		src, err string
+	}{
+		{`<phoneNumberMetadata><territories/></phoneNumberMetadata>`, "no territory found"},
+		{`<phoneNumberMetadata><territories><territory id="XX" countryCode="x"/></territories></phoneNumberMetadata>`, "XX: invalid country code x"},
+		{`<phoneNumberMetadata><territories><territory id="XX" countryCode="1"/></territories></phoneNumberMetadata>`, "XX: missing generalDesc"},
+		{`<phoneNumberMetadata><territories><territory id="XX" countryCode="1"><generalDesc><nationalNumberPattern>\d</nationalNumberPattern></generalDesc><mobile><possibleLengths national="[3-x]"/></mobile></territory></territories></phoneNumberMetadata>`, "XX: invalid possible lengths [3-x]"},
+		{`<phoneNumberMetadata><territories><territory id="XX" countryCode="1"><generalDesc><nationalNumberPattern>(?=1)</nationalNumberPattern></generalDesc><mobile><possibleLengths national="3"/></mobile></territory></territories></phoneNumberMetadata>`, "phone metadata line 19: error parsing regexp: invalid or unsupported Perl syntax: `(?=`"},
+	} {
+		_, err := generate([]byte(tt.src), time.Now())
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("%s: got error %v, want %s", tt.src, err, tt.err)
+		}
+	}
+}
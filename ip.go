@@ -0,0 +1,216 @@
+package vvalidator
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+)
+
+// IPOptions configures ParseIPAddr, ParseCIDR, ValidateIP and ValidateCIDR,
+// the zero value accepts IPv4 and IPv6 addresses without a zone.
+type IPOptions struct {
+	// Version is the allowed IP version, 4 or 6, 0 means both.
+	// IPv4-mapped IPv6 addresses such as "::ffff:192.0.2.1" are IPv6.
+	Version int
+	// AllowZone accepts IPv6 zones, e.g. "fe80::1%eth0".
+	AllowZone bool
+	// Allow are the allowed prefixes, empty means any.
+	Allow []netip.Prefix
+	// Deny are the rejected prefixes.
+	Deny []netip.Prefix
+}
+
+// IsCIDR checks if the string is an IP prefix in CIDR notation, e.g.
+// "192.0.2.0/24" or "2001:db8::/32".
+func IsCIDR(str string) bool {
+	_, err := netip.ParsePrefix(str)
+	return err == nil
+}
+
+// IsIPv4CIDR checks if the string is an IPv4 prefix in CIDR notation.
+func IsIPv4CIDR(str string) bool {
+	p, err := netip.ParsePrefix(str)
+	return err == nil && p.Addr().Is4()
+}
+
+// IsIPv6CIDR checks if the string is an IPv6 prefix in CIDR notation.
+func IsIPv6CIDR(str string) bool {
+	p, err := netip.ParsePrefix(str)
+	return err == nil && p.Addr().Is6()
+}
+
+// IsIPRange checks if the string is an IP range, see ParseIPRange.
+func IsIPRange(str string) bool {
+	_, err := ParseIPRange(str)
+	return err == nil
+}
+
+// IPRange is an inclusive range of IP addresses of the same version.
+type IPRange struct {
+	From, To netip.Addr
+}
+
+// ParseIPRange parses an IP range such as "192.0.2.10-192.0.2.20", the
+// addresses are of the same version and From is not after To.
+func ParseIPRange(str string) (IPRange, error) {
+	i := strings.IndexByte(str, '-')
+	if i < 0 {
+		return IPRange{}, ipError("missing - in range " + str)
+	}
+	from, err := netip.ParseAddr(str[:i])
+	if err != nil || from.Zone() != "" {
+		return IPRange{}, ipError("invalid range start " + str[:i])
+	}
+	to, err := netip.ParseAddr(str[i+1:])
+	if err != nil || to.Zone() != "" {
+		return IPRange{}, ipError("invalid range end " + str[i+1:])
+	}
+	if from.Is4() != to.Is4() {
+		return IPRange{}, ipError("mixed IP versions in range " + str)
+	}
+	if to.Less(from) {
+		return IPRange{}, ipError("range start is after its end in " + str)
+	}
+	return IPRange{From: from, To: to}, nil
+}
+
+// Contains reports whether the address is in the range.
+func (r IPRange) Contains(addr netip.Addr) bool {
+	addr = addr.WithZone("")
+	if addr.Is4In6() && r.From.Is4() {
+		addr = addr.Unmap()
+	}
+	return addr.BitLen() == r.From.BitLen() && !addr.Less(r.From) && !r.To.Less(addr)
+}
+
+// String returns the range as "from-to".
+func (r IPRange) String() string {
+	return r.From.String() + "-" + r.To.String()
+}
+
+// ParseIPAddr parses an IP address and checks it against the options.
+// IPv4-mapped IPv6 addresses are also checked against the IPv4 prefixes of
+// Allow and Deny.
+func ParseIPAddr(str string, opts IPOptions) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(str)
+	if err != nil {
+		return netip.Addr{}, ipError(str)
+	}
+	if addr.Zone() != "" && !opts.AllowZone {
+		return netip.Addr{}, ipError("zone is not allowed in " + str)
+	}
+	if err := checkIPVersion(addr, opts.Version); err != nil {
+		return netip.Addr{}, err
+	}
+	plain := addr.WithZone("")
+	if len(opts.Allow) > 0 && !prefixesContain(opts.Allow, plain) {
+		return netip.Addr{}, ipError(str + " is not in an allowed network")
+	}
+	if prefixesContain(opts.Deny, plain) {
+		return netip.Addr{}, ipError(str + " is in a denied network")
+	}
+	return addr, nil
+}
+
+// ParseCIDR parses an IP prefix in CIDR notation and checks it against the
+// options, it must be within an allowed prefix and not overlap a denied one.
+// The address may have host bits set, e.g. "192.0.2.1/24", use Masked to
+// clear them.
+func ParseCIDR(str string, opts IPOptions) (netip.Prefix, error) {
+	p, err := netip.ParsePrefix(str)
+	if err != nil {
+		return netip.Prefix{}, ipError("invalid CIDR " + str)
+	}
+	if err := checkIPVersion(p.Addr(), opts.Version); err != nil {
+		return netip.Prefix{}, err
+	}
+	if len(opts.Allow) > 0 {
+		allowed := false
+		for _, a := range opts.Allow {
+			if a.Bits() <= p.Bits() && a.Contains(p.Addr()) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return netip.Prefix{}, ipError(str + " is not in an allowed network")
+		}
+	}
+	for _, d := range opts.Deny {
+		if d.Overlaps(p) {
+			return netip.Prefix{}, ipError(str + " overlaps a denied network")
+		}
+	}
+	return p, nil
+}
+
+func ipError(reason string) error {
+	return errors.New("invalid IP address: " + reason)
+}
+
+func checkIPVersion(addr netip.Addr, version int) error {
+	switch {
+	case version == 4 && !addr.Is4():
+		return ipError(addr.String() + " is not IPv4")
+	case version == 6 && !addr.Is6():
+		return ipError(addr.String() + " is not IPv6")
+	}
+	return nil
+}
+
+// prefixesContain reports whether one of the prefixes contains the address
+// or, for IPv4-mapped addresses, its IPv4 address.
+func prefixesContain(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, p := range prefixes {
+		if p.Contains(addr) || addr.Is4In6() && p.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateIP validate IP address, see ParseIPAddr.
+func (v *Validator) ValidateIP(data interface{}, key string, opts IPOptions) (netip.Addr, error) {
+	val, err := v.checkExist(data, key, nil)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	addr, err := ParseIPAddr(val.(string), opts)
+	if err != nil {
+		return netip.Addr{}, v.error(MsgRule, key, val.(string), "rule", "ip")
+	}
+	return addr, nil
+}
+
+// ValidateIPp validate IP address with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateIPp(data interface{}, key string, opts IPOptions, code int, message string) netip.Addr {
+	addr, err := v.ValidateIP(data, key, opts)
+	if err != nil {
+		v.panicError(err, code, message)
+	}
+	return addr
+}
+
+// ValidateCIDR validate IP prefix in CIDR notation, see ParseCIDR.
+func (v *Validator) ValidateCIDR(data interface{}, key string, opts IPOptions) (netip.Prefix, error) {
+	val, err := v.checkExist(data, key, nil)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	p, err := ParseCIDR(val.(string), opts)
+	if err != nil {
+		return netip.Prefix{}, v.error(MsgRule, key, val.(string), "rule", "cidr")
+	}
+	return p, nil
+}
+
+// ValidateCIDRp validate IP prefix in CIDR notation with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateCIDRp(data interface{}, key string, opts IPOptions, code int, message string) netip.Prefix {
+	p, err := v.ValidateCIDR(data, key, opts)
+	if err != nil {
+		v.panicError(err, code, message)
+	}
+	return p
+}
@@ -0,0 +1,155 @@
+package vvalidator
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestIsCIDR(t *testing.T) {
+	equal(t, true, IsCIDR("192.0.2.0/24"))
+	equal(t, true, IsCIDR("192.0.2.1/24"))
+	equal(t, true, IsCIDR("2001:db8::/32"))
+	equal(t, false, IsCIDR("192.0.2.0"))
+	equal(t, false, IsCIDR("192.0.2.0/33"))
+	equal(t, false, IsCIDR("2001:db8::/129"))
+	equal(t, false, IsCIDR("192.0.2.0/024"))
+	equal(t, true, IsIPv4CIDR("10.0.0.0/8"))
+	equal(t, false, IsIPv4CIDR("2001:db8::/32"))
+	equal(t, true, IsIPv6CIDR("2001:db8::/32"))
+	equal(t, true, IsIPv6CIDR("::ffff:192.0.2.0/120"))
+	equal(t, false, IsIPv6CIDR("10.0.0.0/8"))
+
+	equal(t, true, IsIPv4("192.0.2.1"))
+	equal(t, false, IsIPv4("::ffff:192.0.2.1"))
+	equal(t, false, IsIPv4("2001:db8::1"))
+	equal(t, true, IsIPv6("::ffff:192.0.2.1"))
+}
+
+func TestParseIPRange(t *testing.T) {
+	r, err := ParseIPRange("192.0.2.10-192.0.2.20")
+	equal(t, nil, err)
+	equal(t, "192.0.2.10-192.0.2.20", r.String())
+	equal(t, true, r.Contains(netip.MustParseAddr("192.0.2.10")))
+	equal(t, true, r.Contains(netip.MustParseAddr("192.0.2.20")))
+	equal(t, true, r.Contains(netip.MustParseAddr("::ffff:192.0.2.15")))
+	equal(t, false, r.Contains(netip.MustParseAddr("192.0.2.21")))
+	equal(t, false, r.Contains(netip.MustParseAddr("2001:db8::1")))
+
+	r, err = ParseIPRange("2001:db8::-2001:db8::ffff")
+	equal(t, nil, err)
+	equal(t, true, r.Contains(netip.MustParseAddr("2001:db8::1%eth0")))
+
+	for _, tt := range []struct {
+		str string
+		err string
+	}{
+		{"192.0.2.10", "missing - in range 192.0.2.10"},
+		{"192.0.2-192.0.2.20", "invalid range start 192.0.2"},
+		{"192.0.2.10-", "invalid range end "},
+		{"192.0.2.10-2001:db8::1", "mixed IP versions in range 192.0.2.10-2001:db8::1"},
+		{"192.0.2.20-192.0.2.10", "range start is after its end in 192.0.2.20-192.0.2.10"},
+		{"fe80::1%eth0-fe80::2", "invalid range start fe80::1%eth0"},
+	} {
+		_, err := ParseIPRange(tt.str)
+		if err == nil {
+			t.Errorf("%s: expected error %s", tt.str, tt.err)
+		} else {
+			equal(t, "invalid IP address: "+tt.err, err.Error())
+		}
+	}
+	equal(t, true, IsIPRange("10.0.0.1-10.0.0.1"))
+	equal(t, false, IsIPRange("10.0.0.0/8"))
+}
+
+func TestParseIPAddr(t *testing.T) {
+	addr, err := ParseIPAddr("fe80::1%eth0", IPOptions{AllowZone: true})
+	equal(t, nil, err)
+	equal(t, "eth0", addr.Zone())
+
+	private := IPOptions{
+		Allow: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8")},
+		Deny:  []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")},
+	}
+	for _, tt := range []struct {
+		str  string
+		opts IPOptions
+		err  string
+	}{
+		{"192.0.2.1", IPOptions{}, ""},
+		{"2001:db8::1", IPOptions{}, ""},
+		{"192.0.2.1", IPOptions{Version: 4}, ""},
+		{"2001:db8::1", IPOptions{Version: 6}, ""},
+		{"10.1.2.3", private, ""},
+		{"fd00::1", private, ""},
+		{"::ffff:10.1.2.3", private, ""},
+		{"192.0.2", IPOptions{}, "192.0.2"},
+		{"192.0.2.01", IPOptions{}, "192.0.2.01"},
+		{"fe80::1%eth0", IPOptions{}, "zone is not allowed in fe80::1%eth0"},
+		{"2001:db8::1", IPOptions{Version: 4}, "2001:db8::1 is not IPv4"},
+		{"::ffff:192.0.2.1", IPOptions{Version: 4}, "::ffff:192.0.2.1 is not IPv4"},
+		{"192.0.2.1", IPOptions{Version: 6}, "192.0.2.1 is not IPv6"},
+		{"192.0.2.1", private, "192.0.2.1 is not in an allowed network"},
+		{"10.0.0.7", private, "10.0.0.7 is in a denied network"},
+		{"::ffff:10.0.0.7", private, "::ffff:10.0.0.7 is in a denied network"},
+	} {
+		_, err := ParseIPAddr(tt.str, tt.opts)
+		if tt.err == "" {
+			equal(t, nil, err)
+		} else if err == nil {
+			t.Errorf("%s: expected error %s", tt.str, tt.err)
+		} else {
+			equal(t, "invalid IP address: "+tt.err, err.Error())
+		}
+	}
+}
+
+func TestParseCIDR(t *testing.T) {
+	p, err := ParseCIDR("192.0.2.1/24", IPOptions{})
+	equal(t, nil, err)
+	equal(t, "192.0.2.0/24", p.Masked().String())
+
+	opts := IPOptions{
+		Version: 4,
+		Allow:   []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+		Deny:    []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")},
+	}
+	for _, tt := range []struct {
+		str string
+		err string
+	}{
+		{"10.1.0.0/16", ""},
+		{"10.0.0.0/8", "10.0.0.0/8 overlaps a denied network"},
+		{"10.0.0.128/25", "10.0.0.128/25 overlaps a denied network"},
+		{"0.0.0.0/0", "0.0.0.0/0 is not in an allowed network"},
+		{"192.0.2.0/24", "192.0.2.0/24 is not in an allowed network"},
+		{"2001:db8::/32", "2001:db8:: is not IPv4"},
+		{"10.0.0.0", "invalid CIDR 10.0.0.0"},
+	} {
+		_, err := ParseCIDR(tt.str, opts)
+		if tt.err == "" {
+			equal(t, nil, err)
+		} else if err == nil {
+			t.Errorf("%s: expected error %s", tt.str, tt.err)
+		} else {
+			equal(t, "invalid IP address: "+tt.err, err.Error())
+		}
+	}
+}
+
+func TestValidateIP(t *testing.T) {
+	params := map[string]string{"client": "10.1.2.3", "server": "2001:db8::1", "network": "10.0.0.0/8"}
+	opts := IPOptions{Version: 4}
+	addr, err := ValidateIP(params, "client", opts)
+	equal(t, nil, err)
+	equal(t, netip.MustParseAddr("10.1.2.3"), addr)
+	_, err = ValidateIP(params, "server", opts)
+	equal(t, "server must be a valid ip", err.Error())
+	_, err = ValidateIP(params, "missing", opts)
+	equal(t, "missing is required", err.Error())
+
+	p, err := ValidateCIDR(params, "network", opts)
+	equal(t, nil, err)
+	equal(t, 8, p.Bits())
+	_, err = ValidateCIDR(params, "client", opts)
+	equal(t, "client must be a valid cidr", err.Error())
+}
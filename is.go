@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -65,6 +66,8 @@ const (
 	PatternHexColor = "^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$"
 	// PatternRGBColor is RGB color
 	PatternRGBColor = "^rgb\\(\\s*(0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*(0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*(0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*\\)$"
+	// PatternUUID is UUID
+	PatternUUID = "^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"
 	// PatternRGBAColor is RGB color
 	PatternRGBAColor = "^rgba\\(\\s*(0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*(0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*(0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*((0\\.[0-9]{1})|(1\\.0)|(1))\\)$"
 )
@@ -107,10 +110,10 @@ func IsIP(str string) bool {
 // IsIPv4 checks if the string is valid IPv4.
 func IsIPv4(str string) bool {
 	ip := net.ParseIP(str)
-	if ip == nil {
+	if ip == nil || ip.To4() == nil {
 		return false
 	}
-	return strings.Contains(str, ".")
+	return !strings.Contains(str, ":")
 }
 
 // IsIPv6 checks if the string is valid IPv6.
@@ -160,9 +163,10 @@ func IsPrintableASCII(str string) bool {
 	return regexp.MustCompile(PatternPrintableASCII).MatchString(str)
 }
 
-// IsEmail checks if the string is email.
+// IsEmail checks if the string is email, it's ParseEmail with DefaultEmailOptions.
 func IsEmail(str string) bool {
-	return regexp.MustCompile(PatternEmail).MatchString(str)
+	_, err := ParseEmail(str, DefaultEmailOptions)
+	return err == nil
 }
 
 // IsWinPath checks if the string is windows path.
@@ -203,29 +207,33 @@ func IsHalfWidth(str string) bool {
 // 'ripemd128', 'ripemd160', 'tiger128', 'tiger160', 'tiger192',
 // 'crc32', 'crc32b']
 func IsHash(str, algorithm string) bool {
-	length := "0"
-	algo := strings.ToLower(algorithm)
-
-	switch algo {
-	case "crc32", "crc32b":
-		length = "8"
-	case "md5", "md4", "ripemd128", "tiger128":
-		length = "32"
-	case "sha1", "ripemd160", "tiger160":
-		length = "40"
-	case "tiger192":
-		length = "48"
-	case "sha256":
-		length = "64"
-	case "sha384":
-		length = "96"
-	case "sha512":
-		length = "128"
-	default:
+	length, ok := hashLengths[strings.ToLower(algorithm)]
+	if !ok {
 		return false
 	}
 
-	return regexp.MustCompile("^[a-f0-9]{" + length + "}$").MatchString(str)
+	return regexp.MustCompile("^[a-f0-9]{" + strconv.Itoa(length) + "}$").MatchString(str)
+}
+
+// hashLengths are the lengths of the hex digests of the IsHash algorithms.
+var hashLengths = map[string]int{
+	"crc32": 8, "crc32b": 8,
+	"md5": 32, "md4": 32, "ripemd128": 32, "tiger128": 32,
+	"sha1": 40, "ripemd160": 40, "tiger160": 40,
+	"tiger192": 48,
+	"sha256":   64,
+	"sha384":   96,
+	"sha512":   128,
+}
+
+// HashAlgorithms returns the algorithms supported by IsHash, sorted.
+func HashAlgorithms() []string {
+	algos := make([]string, 0, len(hashLengths))
+	for algo := range hashLengths {
+		algos = append(algos, algo)
+	}
+	sort.Strings(algos)
+	return algos
 }
 
 // IsMAC check if a string is valid MAC address.
@@ -299,6 +307,11 @@ func IsRGBAColor(str string) bool {
 	return regexp.MustCompile(PatternRGBAColor).MatchString(str)
 }
 
+// IsUUID check if the string is a UUID like 6ba7b810-9dad-11d1-80b4-00c04fd430c8.
+func IsUUID(str string) bool {
+	return regexp.MustCompile(PatternUUID).MatchString(str)
+}
+
 // IsLowerCase check if the string is lowercase.
 func IsLowerCase(str string) bool {
 	return str == strings.ToLower(str)
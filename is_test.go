@@ -14,3 +14,10 @@ func TestIs(t *testing.T) {
 	rgba := IsRGBAColor("rgba(255,255,255,0.1)")
 	equal(t, true, rgba)
 }
+
+func TestIsHash(t *testing.T) {
+	equal(t, true, IsHash("d41d8cd98f00b204e9800998ecf8427e", "MD5"))
+	equal(t, false, IsHash("d41d8cd98f00b204e9800998ecf8427e", "sha1"))
+	equal(t, false, IsHash("d41d8cd98f00b204e9800998ecf8427e", "sha3-256"))
+	equal(t, []string{"crc32", "crc32b", "md4", "md5", "ripemd128", "ripemd160", "sha1", "sha256", "sha384", "sha512", "tiger128", "tiger160", "tiger192"}, HashAlgorithms())
+}
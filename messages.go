@@ -0,0 +1,125 @@
+package vvalidator
+
+import (
+	"strings"
+)
+
+// Message ids of the message catalog, the templates may use the
+// placeholders {field}, {label}, {key}, {value}, {min}, {max}, {rule} and {types}.
+const (
+	MsgRequired   = "required"
+	MsgEmpty      = "empty"
+	MsgDataType   = "data_type"
+	MsgType       = "type"
+	MsgFieldType  = "field_type"
+	MsgInt        = "int"
+	MsgInt64      = "int64"
+	MsgFloat      = "float"
+	MsgMin        = "min"
+	MsgMax        = "max"
+	MsgMinLength  = "min_length"
+	MsgMaxLength  = "max_length"
+	MsgMinItems   = "min_items"
+	MsgMaxItems   = "max_items"
+	MsgPattern    = "pattern"
+	MsgInvalid    = "invalid"
+	MsgRule       = "rule"
+	MsgNotAllowed = "not_allowed"
+)
+
+// DefaultMessages is the built-in English message catalog.
+var DefaultMessages = map[string]string{
+	MsgRequired:   "{field} is required",
+	MsgEmpty:      "{field} can't be empty",
+	MsgDataType:   "data type invalid, must be {types}",
+	MsgType:       "type invalid, must be {types}",
+	MsgFieldType:  "{field} must be of type {types}",
+	MsgInt:        "{field} must be an integer",
+	MsgInt64:      "{field} must be a valid interger",
+	MsgFloat:      "{field} must be a valid float64",
+	MsgMin:        "{field} is too small (minimum is {min})",
+	MsgMax:        "{field} is too big (maximum is {max})",
+	MsgMinLength:  "{field} is too short (minimum is {min} characters)",
+	MsgMaxLength:  "{field} is too long (maximum is {max} characters)",
+	MsgMinItems:   "{field} is too short (minimum is {min} elements)",
+	MsgMaxItems:   "{field} is too long (maximum is {max} elements)",
+	MsgPattern:    "{field} must be a valid string",
+	MsgInvalid:    "{field} is invalid",
+	MsgRule:       "{field} must be a valid {rule}",
+	MsgNotAllowed: "{field} is not allowed",
+}
+
+// template returns the message template of id for the field key.
+// Templates set for "key.id" win over those for id, and Messages over the catalogs.
+func (v *Validator) template(id, key string) string {
+	if tpl, ok := v.Messages[key+"."+id]; ok {
+		return tpl
+	}
+	if tpl, ok := v.Messages[id]; ok {
+		return tpl
+	}
+	if tpl, ok := v.catalogMessage(id, key); ok {
+		return tpl
+	}
+	return DefaultMessages[id]
+}
+
+// render fills the placeholders of a template from a validation error:
+// {field} and {label} are the field label, {key} the field key, {value}
+// the invalid value and the error params by name, e.g. {min} and {max}.
+func (v *Validator) render(tpl string, e Error) string {
+	if !strings.Contains(tpl, "{") {
+		return tpl
+	}
+	label := v.label(e.Field)
+	pairs := []string{"{field}", label, "{label}", label, "{key}", e.Field, "{value}", e.Value}
+	for name, val := range e.Params {
+		pairs = append(pairs, "{"+name+"}", val)
+	}
+	return strings.NewReplacer(pairs...).Replace(tpl)
+}
+
+// error returns the validation error of message id for the field key and
+// its value, params are name, value pairs.
+func (v *Validator) error(id, key, value string, params ...string) error {
+	return v.FieldError(id, key, value, params...)
+}
+
+// FieldError returns the validation error of message id for the field key
+// and its value, params are name, value pairs such as "min", "0". It lets
+// other packages report errors with the messages of the validator.
+func (v *Validator) FieldError(id, key, value string, params ...string) Error {
+	e := Error{
+		Code:  v.code(),
+		Field: key,
+		Path:  key,
+		Rule:  id,
+		Value: value,
+	}
+	if v.Sensitive[key] {
+		e = e.Redact()
+	}
+	if len(params) > 0 {
+		e.Params = make(map[string]string, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			e.Params[params[i]] = params[i+1]
+		}
+		if rule, ok := e.Params["rule"]; ok {
+			e.Rule = rule
+		}
+	}
+	e.Message = v.render(v.template(id, key), e)
+	return e
+}
+
+// bounds returns the min and max params of a range, -1 means no bound.
+func bounds(min, max string) []string {
+	var params []string
+	if min != "-1" {
+		params = append(params, "min", min)
+	}
+	if max != "-1" {
+		params = append(params, "max", max)
+	}
+	return params
+}
@@ -0,0 +1,40 @@
+package vvalidator
+
+import (
+	"testing"
+)
+
+func TestMessages(t *testing.T) {
+	params := map[string]string{
+		"uid":      "123",
+		"nickname": "fengmoti",
+	}
+
+	v := New()
+	v.Labels["uid"] = "User ID"
+	v.Messages[MsgMax] = "{label} must be between {min} and {max}"
+	v.Messages["nickname.max_length"] = "{label} is limited to {max} characters, got {value}"
+
+	_, err := v.ValidateInt(params, "uid", 0, 10)
+	equal(t, Error{
+		Message: "User ID must be between 0 and 10",
+		Code:    DefaultCode,
+		Field:   "uid",
+		Path:    "uid",
+		Rule:    MsgMax,
+		Params:  map[string]string{"min": "0", "max": "10"},
+		Value:   "123",
+	}, err)
+	_, err = v.ValidateString(params, "nickname", -1, 5)
+	equal(t, "nickname is limited to 5 characters, got fengmoti", err.Error())
+	equal(t, "User ID must be a valid email", v.ValidateRule(params, "uid", "email").Error())
+
+	defer func() {
+		e := recover().(Error)
+		equal(t, 422, e.Code)
+		equal(t, "User ID is too small (minimum is 200)", e.Message)
+		equal(t, "User ID: 123 is not in [200, 300]", e.CustomMessage)
+	}()
+	v.Messages = nil
+	v.ValidateIntp(params, "uid", 200, 300, 422, "{label}: {value} is not in [{min}, {max}]")
+}
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/syyongx/vvalidator"
+)
+
+// Rules are the rule strings a handler validates its request with, by
+// parameter or field name, e.g.
+//
+//	openapi.Rules{
+//	    Query:    map[string]string{"limit": "int|min:1|max:100"},
+//	    Path:     map[string]string{"id": "required|int64|min:1"},
+//	    Defaults: map[string]string{"limit": "20"},
+//	    Body:     createUser{},
+//	}
+type Rules struct {
+	Query  map[string]string
+	Header map[string]string
+	Path   map[string]string
+	Cookie map[string]string
+	// Defaults are the default values of the parameters.
+	Defaults map[string]string
+	// Body describes the JSON request body: the rule strings of its fields
+	// as map[string]string, or a struct with `valid` tags.
+	Body interface{}
+}
+
+// Operation returns the operation with the parameters and request body
+// described by the rules, parameters are sorted by location and name.
+func (r Rules) Operation() (*Operation, error) {
+	op := &Operation{}
+	for _, scope := range []struct {
+		in    string
+		rules map[string]string
+	}{
+		{"path", r.Path},
+		{"query", r.Query},
+		{"header", r.Header},
+		{"cookie", r.Cookie},
+	} {
+		params, err := r.parameters(scope.in, scope.rules)
+		if err != nil {
+			return nil, err
+		}
+		op.Parameters = append(op.Parameters, params...)
+	}
+
+	body, err := r.RequestBody()
+	if err != nil {
+		return nil, err
+	}
+	op.RequestBody = body
+	return op, nil
+}
+
+// Parameters returns the OpenAPI parameters described by the rules.
+func (r Rules) Parameters() ([]*Parameter, error) {
+	op, err := r.Operation()
+	if err != nil {
+		return nil, err
+	}
+	return op.Parameters, nil
+}
+
+func (r Rules) parameters(in string, rules map[string]string) ([]*Parameter, error) {
+	object, err := vvalidator.RulesSchema(rules)
+	if err != nil {
+		return nil, err
+	}
+	props := object["properties"].(map[string]interface{})
+	required, _ := object["required"].([]string)
+
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	params := make([]*Parameter, 0, len(names))
+	for _, name := range names {
+		schema := props[name].(map[string]interface{})
+		if def, ok := r.Defaults[name]; ok {
+			schema["default"] = defaultValue(def, schema["type"])
+		}
+		params = append(params, &Parameter{
+			Name:   name,
+			In:     in,
+			Schema: schema,
+			// Path parameters are always required.
+			Required: in == "path" || contains(required, name),
+		})
+	}
+	return params, nil
+}
+
+// RequestBody returns the OpenAPI request body described by the rules,
+// nil if there is no Body.
+func (r Rules) RequestBody() (*RequestBody, error) {
+	var schema map[string]interface{}
+	var err error
+	switch body := r.Body.(type) {
+	case nil:
+		return nil, nil
+	case map[string]string:
+		schema, err = vvalidator.RulesSchema(body)
+	default:
+		schema, err = vvalidator.StructSchema(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, required := schema["required"]
+	return &RequestBody{
+		Required: required,
+		Content:  map[string]*MediaType{"application/json": {Schema: schema}},
+	}, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultValue returns a default value as a JSON value of the schema type.
+func defaultValue(def string, typ interface{}) interface{} {
+	switch typ {
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(def, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(def); err == nil {
+			return b
+		}
+	}
+	return def
+}
@@ -0,0 +1,165 @@
+// Package openapi connects vvalidator rules with OpenAPI 3 documents.
+//
+// Rules describes the rule strings attached to a handler and generates the
+// OpenAPI parameters and requestBody of its operation. Conversely, a
+// Validator validates incoming requests against the operations of a
+// document loaded from a local YAML or JSON file.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is an OpenAPI 3 document, only the parts used for validation
+// are decoded.
+type Document struct {
+	OpenAPI    string                 `json:"openapi" yaml:"openapi"`
+	Paths      map[string]*PathItem   `json:"paths" yaml:"paths"`
+	Components map[string]interface{} `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// PathItem holds the operations of a path by method.
+type PathItem struct {
+	Parameters []*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Get        *Operation   `json:"get,omitempty" yaml:"get,omitempty"`
+	Put        *Operation   `json:"put,omitempty" yaml:"put,omitempty"`
+	Post       *Operation   `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete     *Operation   `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Options    *Operation   `json:"options,omitempty" yaml:"options,omitempty"`
+	Head       *Operation   `json:"head,omitempty" yaml:"head,omitempty"`
+	Patch      *Operation   `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Trace      *Operation   `json:"trace,omitempty" yaml:"trace,omitempty"`
+}
+
+// Operation is an API operation, e.g. "GET /users/{id}".
+type Operation struct {
+	OperationID string       `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Parameters  []*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+}
+
+// Parameter is a query, header, path or cookie parameter of an operation.
+type Parameter struct {
+	Ref         string                 `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Name        string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	In          string                 `json:"in,omitempty" yaml:"in,omitempty"`
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool                   `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// RequestBody is the request body of an operation by media type.
+type RequestBody struct {
+	Ref         string                `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty" yaml:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// MediaType holds the schema of a request body media type.
+type MediaType struct {
+	Schema map[string]interface{} `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// methods returns the operations of the path item by method.
+func (p *PathItem) methods() map[string]*Operation {
+	return map[string]*Operation{
+		"GET":     p.Get,
+		"PUT":     p.Put,
+		"POST":    p.Post,
+		"DELETE":  p.Delete,
+		"OPTIONS": p.Options,
+		"HEAD":    p.Head,
+		"PATCH":   p.Patch,
+		"TRACE":   p.Trace,
+	}
+}
+
+// Load reads an OpenAPI document from a YAML or JSON file.
+func Load(filename string) (*Document, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses an OpenAPI document in YAML or JSON.
+func Parse(data []byte) (*Document, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.New("invalid openapi document: " + err.Error())
+	}
+	// Decode through JSON so that the schemas hold JSON values only.
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, errors.New("invalid openapi document: " + err.Error())
+	}
+	doc := &Document{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		return nil, errors.New("invalid openapi document: " + err.Error())
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, errors.New("unsupported openapi version " + doc.OpenAPI)
+	}
+	return doc, nil
+}
+
+// component returns the component a local $ref such as
+// "#/components/parameters/limit" points to, decoded into v.
+func (d *Document) component(ref string, v interface{}) error {
+	parts := strings.Split(strings.TrimPrefix(ref, "#/components/"), "/")
+	if !strings.HasPrefix(ref, "#/components/") || len(parts) != 2 {
+		return errors.New("unsupported $ref " + ref)
+	}
+	group, _ := d.Components[parts[0]].(map[string]interface{})
+	raw, ok := group[parts[1]]
+	if !ok {
+		return errors.New("$ref " + ref + " not found")
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// normalize rewrites the OpenAPI 3.0 forms of a schema into JSON Schema
+// draft 2020-12 ones: "nullable" and the boolean exclusive bounds.
+func normalize(schema interface{}) interface{} {
+	switch s := schema.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(s))
+		for k, v := range s {
+			out[k] = normalize(v)
+		}
+		if nullable, _ := out["nullable"].(bool); nullable {
+			if t, ok := out["type"].(string); ok {
+				out["type"] = []interface{}{t, "null"}
+			}
+		}
+		delete(out, "nullable")
+		for exclusive, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+			if b, ok := out[exclusive].(bool); ok {
+				delete(out, exclusive)
+				if b {
+					out[exclusive] = out[bound]
+					delete(out, bound)
+				}
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(s))
+		for i, v := range s {
+			out[i] = normalize(v)
+		}
+		return out
+	}
+	return schema
+}
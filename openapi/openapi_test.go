@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func equal(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+	}
+}
+
+func TestRules(t *testing.T) {
+	type createUser struct {
+		Email    string `json:"email" valid:"required|email"`
+		Nickname string `json:"nickname" valid:"max:10"`
+	}
+	op, err := Rules{
+		Query:    map[string]string{"limit": "int|min:1|max:100", "status": "in:active,disabled"},
+		Header:   map[string]string{"X-Request-ID": "required|uuid"},
+		Path:     map[string]string{"id": "int64|min:1"},
+		Defaults: map[string]string{"limit": "20"},
+		Body:     createUser{},
+	}.Operation()
+	equal(t, nil, err)
+	b, _ := json.Marshal(op)
+	equal(t, `{"parameters":[`+
+		`{"name":"id","in":"path","required":true,"schema":{"minimum":1,"type":"integer"}},`+
+		`{"name":"limit","in":"query","schema":{"default":20,"maximum":100,"minimum":1,"type":"integer"}},`+
+		`{"name":"status","in":"query","schema":{"enum":["active","disabled"],"type":"string"}},`+
+		`{"name":"X-Request-ID","in":"header","required":true,"schema":{"format":"uuid","type":"string"}}],`+
+		`"requestBody":{"required":true,"content":{"application/json":{"schema":{"properties":{`+
+		`"email":{"format":"email","type":"string"},"nickname":{"maxLength":10,"type":"string"}},`+
+		`"required":["email"],"type":"object"}}}}}`, string(b))
+}
+
+func TestValidateRequest(t *testing.T) {
+	doc, err := Load("testdata/users.yaml")
+	equal(t, nil, err)
+	val, err := NewValidator(doc, nil)
+	equal(t, nil, err)
+
+	validate := func(method, target, body string, header ...string) string {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		for i := 0; i+1 < len(header); i += 2 {
+			r.Header.Set(header[i], header[i+1])
+		}
+		if err := val.ValidateRequest(r); err != nil {
+			return err.Error()
+		}
+		return ""
+	}
+	uuid := "0c5e0e0a-2b6f-4c57-9b0e-3f8f5c1d2a4b"
+	equal(t, "", validate("GET", "/users?limit=99&status=active", "", "X-Request-ID", uuid))
+	equal(t, "limit is too big (maximum is 100); status is invalid; X-Request-ID is required",
+		validate("GET", "/users?limit=100&status=x", ""))
+	equal(t, "limit must be of type integer", validate("GET", "/users?limit=a", "", "X-Request-ID", uuid))
+	equal(t, "", validate("GET", "/users/me", ""))
+	equal(t, "", validate("GET", "/users/12", ""))
+	equal(t, "id is too small (minimum is 1)", validate("GET", "/users/0", ""))
+	equal(t, "no operation for DELETE /users/1", validate(http.MethodDelete, "/users/1", ""))
+
+	equal(t, "", validate("POST", "/users", `{"email": "a@b.com", "nickname": null}`))
+	equal(t, "body is required", validate("POST", "/users", ""))
+	equal(t, "email must be a valid email; nickname is too long (maximum is 10 characters)",
+		validate("POST", "/users", `{"email": "a", "nickname": "abcdefghijk"}`))
+	equal(t, "body must be of type application/json", validate("POST", "/users", "a=b", "Content-Type", "text/plain"))
+
+	val.MaxBodySize = 16
+	equal(t, "http: request body too large", validate("POST", "/users", `{"email": "a@b.com"}`))
+}
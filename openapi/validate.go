@@ -0,0 +1,313 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/syyongx/vvalidator"
+)
+
+// DefaultMaxBodySize is the maximum size of request bodies read by
+// ValidateRequest, unless Validator.MaxBodySize is set.
+const DefaultMaxBodySize = 1 << 20
+
+// Validator validates requests against the operations of a document,
+// the schemas are compiled once by NewValidator.
+type Validator struct {
+	// MaxBodySize is the maximum size in bytes of request bodies, 0 means
+	// DefaultMaxBodySize.
+	MaxBodySize int64
+
+	v      *vvalidator.Validator
+	routes []*route
+}
+
+type route struct {
+	method   string
+	path     string
+	segments []string
+	literals int
+	params   []*param
+	body     *body
+}
+
+type param struct {
+	*Parameter
+	schema *vvalidator.Schema
+}
+
+type body struct {
+	required bool
+	types    []string
+	schemas  map[string]*vvalidator.Schema
+}
+
+// NewValidator returns a validator of the requests of doc, using v for
+// error messages, nil means vvalidator.Default(). Parameters and schemas
+// may $ref the document components.
+func NewValidator(doc *Document, v *vvalidator.Validator) (*Validator, error) {
+	if v == nil {
+		v = vvalidator.Default()
+	}
+	val := &Validator{v: v}
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for method, op := range item.methods() {
+			if op == nil {
+				continue
+			}
+			rt, err := doc.route(method, path, item, op)
+			if err != nil {
+				return nil, errors.New(method + " " + path + ": " + err.Error())
+			}
+			val.routes = append(val.routes, rt)
+		}
+	}
+	// Literal segments win over templated ones, e.g. /users/me over /users/{id}.
+	sort.SliceStable(val.routes, func(i, j int) bool {
+		return val.routes[i].literals > val.routes[j].literals
+	})
+	return val, nil
+}
+
+func (d *Document) route(method, path string, item *PathItem, op *Operation) (*route, error) {
+	rt := &route{method: method, path: path, segments: strings.Split(strings.Trim(path, "/"), "/")}
+	for _, seg := range rt.segments {
+		if !strings.HasPrefix(seg, "{") {
+			rt.literals++
+		}
+	}
+
+	// Operation parameters override the path item ones with the same name and location.
+	byKey := make(map[string]*Parameter)
+	var keys []string
+	for _, p := range append(append([]*Parameter{}, item.Parameters...), op.Parameters...) {
+		if p.Ref != "" {
+			resolved := &Parameter{}
+			if err := d.component(p.Ref, resolved); err != nil {
+				return nil, err
+			}
+			p = resolved
+		}
+		key := p.In + ":" + p.Name
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = p
+	}
+	for _, key := range keys {
+		p := byKey[key]
+		// Parameters are validated as the properties of form values.
+		var ps interface{} = true
+		if p.Schema != nil {
+			ps = p.Schema
+		}
+		schema, err := d.compile(map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{p.Name: ps},
+		})
+		if err != nil {
+			return nil, err
+		}
+		rt.params = append(rt.params, &param{Parameter: p, schema: schema})
+	}
+
+	rb := op.RequestBody
+	if rb != nil && rb.Ref != "" {
+		rb = &RequestBody{}
+		if err := d.component(op.RequestBody.Ref, rb); err != nil {
+			return nil, err
+		}
+	}
+	if rb != nil {
+		rt.body = &body{required: rb.Required, schemas: make(map[string]*vvalidator.Schema)}
+		for typ, mt := range rb.Content {
+			rt.body.types = append(rt.body.types, typ)
+			if mt == nil || mt.Schema == nil || !isJSON(typ) {
+				continue
+			}
+			schema, err := d.compile(mt.Schema)
+			if err != nil {
+				return nil, err
+			}
+			rt.body.schemas[typ] = schema
+		}
+		sort.Strings(rt.body.types)
+	}
+	return rt, nil
+}
+
+// compile compiles a schema of the document, the components are added to
+// it so that "#/components/schemas/..." references resolve.
+func (d *Document) compile(schema map[string]interface{}) (*vvalidator.Schema, error) {
+	doc := normalize(schema).(map[string]interface{})
+	if d.Components != nil {
+		doc["components"] = normalize(d.Components)
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return vvalidator.CompileSchema(b)
+}
+
+// ValidateRequest validates the parameters and JSON body of r against its
+// operation, the validation errors are returned as vvalidator.Errors. The
+// body is read and replaced so that handlers can read it again, bodies
+// larger than MaxBodySize are an *http.MaxBytesError. Requests that match
+// no operation are an error too.
+func (val *Validator) ValidateRequest(r *http.Request) error {
+	rt, pathParams := val.match(r.Method, r.URL.Path)
+	if rt == nil {
+		return errors.New("no operation for " + r.Method + " " + r.URL.Path)
+	}
+
+	var errs vvalidator.Errors
+	for _, p := range rt.params {
+		value, ok := paramValue(r, p.Parameter, pathParams)
+		if !ok {
+			if p.Required {
+				errs = append(errs, val.v.FieldError(vvalidator.MsgRequired, p.Name, ""))
+			}
+			continue
+		}
+		err := val.v.ValidateSchema(map[string]string{p.Name: value}, p.schema)
+		if errs, err = appendErrors(errs, err); err != nil {
+			return err
+		}
+	}
+
+	if rt.body != nil {
+		err := val.validateBody(r, rt.body)
+		if errs, err = appendErrors(errs, err); err != nil {
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (val *Validator) validateBody(r *http.Request, b *body) error {
+	var data []byte
+	if r.Body != nil {
+		var err error
+		limit := val.MaxBodySize
+		if limit <= 0 {
+			limit = DefaultMaxBodySize
+		}
+		if data, err = io.ReadAll(http.MaxBytesReader(nil, r.Body, limit)); err != nil {
+			return err
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(data))
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		if b.required {
+			return val.v.FieldError(vvalidator.MsgRequired, "body", "")
+		}
+		return nil
+	}
+
+	typ := r.Header.Get("Content-Type")
+	if typ == "" {
+		typ = "application/json"
+	}
+	if mt, _, err := mime.ParseMediaType(typ); err == nil {
+		typ = mt
+	}
+	schema, ok := b.schemas[typ]
+	if !ok {
+		for _, declared := range b.types {
+			if declared == typ {
+				// Declared but not JSON, there is nothing to validate.
+				return nil
+			}
+		}
+		return val.v.FieldError(vvalidator.MsgFieldType, "body", typ, "types", strings.Join(b.types, " or "))
+	}
+	return val.v.ValidateSchema(data, schema)
+}
+
+// match returns the route of method and path and the path parameters.
+func (val *Validator) match(method, path string) (*route, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, rt := range val.routes {
+		if rt.method != method || len(rt.segments) != len(segments) {
+			continue
+		}
+		params := make(map[string]string)
+		ok := true
+		for i, seg := range rt.segments {
+			if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") && segments[i] != "" {
+				params[seg[1:len(seg)-1]] = segments[i]
+			} else if seg != segments[i] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return rt, params
+		}
+	}
+	return nil, nil
+}
+
+// paramValue returns the value of a parameter in the request.
+func paramValue(r *http.Request, p *Parameter, pathParams map[string]string) (string, bool) {
+	switch p.In {
+	case "path":
+		v, ok := pathParams[p.Name]
+		return v, ok
+	case "query":
+		vs, ok := r.URL.Query()[p.Name]
+		if !ok || len(vs) == 0 {
+			return "", false
+		}
+		return vs[0], true
+	case "header":
+		vs := r.Header.Values(p.Name)
+		if len(vs) == 0 {
+			return "", false
+		}
+		return vs[0], true
+	case "cookie":
+		c, err := r.Cookie(p.Name)
+		if err != nil {
+			return "", false
+		}
+		return c.Value, true
+	}
+	return "", false
+}
+
+func isJSON(typ string) bool {
+	return typ == "application/json" || strings.HasSuffix(typ, "+json")
+}
+
+// appendErrors appends the validation errors of err, other errors are returned.
+func appendErrors(errs vvalidator.Errors, err error) (vvalidator.Errors, error) {
+	switch e := err.(type) {
+	case nil:
+	case vvalidator.Error:
+		errs = append(errs, e)
+	case vvalidator.Errors:
+		errs = append(errs, e...)
+	default:
+		return errs, err
+	}
+	return errs, nil
+}
@@ -0,0 +1,386 @@
+package vvalidator
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ./internal/phonemeta -o phone_metadata.txt
+
+//go:embed phone_metadata.txt
+var phoneMetadataList string
+
+// PhoneType is the type of a phone number.
+type PhoneType int
+
+// Phone number types, numbers whose patterns are the same for fixed-line
+// and mobile numbers, as in the US, are PhoneFixedLineOrMobile.
+const (
+	PhoneUnknown PhoneType = iota
+	PhoneFixedLine
+	PhoneMobile
+	PhoneFixedLineOrMobile
+)
+
+// String returns the name of the phone type.
+func (t PhoneType) String() string {
+	switch t {
+	case PhoneFixedLine:
+		return "fixed-line"
+	case PhoneMobile:
+		return "mobile"
+	case PhoneFixedLineOrMobile:
+		return "fixed-line or mobile"
+	}
+	return "unknown"
+}
+
+// Phone is a parsed phone number.
+type Phone struct {
+	// CountryCode is the country calling code, e.g. 44.
+	CountryCode int
+	// NationalNumber is the national significant number, without national
+	// prefix, e.g. "2079460958" for "020 7946 0958".
+	NationalNumber string
+	// Region is the ISO 3166-1 alpha-2 code of the region of the number, or
+	// "001" for non-geographic numbers.
+	Region string
+	// Type is the type of the number, if known.
+	Type PhoneType
+}
+
+// E164 returns the number in the E.164 format, e.g. "+442079460958".
+func (p *Phone) E164() string {
+	return "+" + strconv.Itoa(p.CountryCode) + p.NationalNumber
+}
+
+// phoneRegion is the metadata of a region, see phone_metadata.txt.
+type phoneRegion struct {
+	region    string
+	code      int
+	main      bool
+	intl      *regexp.Regexp
+	national  *regexp.Regexp
+	transform string
+	leading   *regexp.Regexp
+	lengths   []int
+	general   *regexp.Regexp
+	fixed     *regexp.Regexp
+	mobile    *regexp.Regexp
+}
+
+type phoneData struct {
+	regions map[string]*phoneRegion
+	// codes maps country calling codes onto their regions, main first.
+	codes map[int][]*phoneRegion
+}
+
+var phoneMetadata struct {
+	once sync.Once
+	mu   sync.RWMutex
+	data *phoneData
+}
+
+// loadPhoneData parses the built-in metadata on first use.
+func loadPhoneData() *phoneData {
+	phoneMetadata.once.Do(func() {
+		data, err := parsePhoneMetadata(strings.NewReader(phoneMetadataList))
+		if err != nil {
+			panic(err)
+		}
+		phoneMetadata.mu.Lock()
+		phoneMetadata.data = data
+		phoneMetadata.mu.Unlock()
+	})
+	phoneMetadata.mu.RLock()
+	defer phoneMetadata.mu.RUnlock()
+	return phoneMetadata.data
+}
+
+// LoadPhoneMetadata replaces the phone number metadata with the metadata
+// read from r, in the format of the built-in phone_metadata.txt.
+func LoadPhoneMetadata(r io.Reader) error {
+	data, err := parsePhoneMetadata(r)
+	if err != nil {
+		return err
+	}
+	loadPhoneData()
+	phoneMetadata.mu.Lock()
+	phoneMetadata.data = data
+	phoneMetadata.mu.Unlock()
+	return nil
+}
+
+func parsePhoneMetadata(r io.Reader) (*phoneData, error) {
+	data := &phoneData{regions: make(map[string]*phoneRegion), codes: make(map[int][]*phoneRegion)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		region, err := parsePhoneRegion(strings.Split(text, "\t"))
+		if err != nil {
+			return nil, errors.New("phone metadata line " + strconv.Itoa(line) + ": " + err.Error())
+		}
+		// Non-geographic numbers share the region 001, it can't be a default region.
+		if region.region != "001" {
+			data.regions[region.region] = region
+		}
+		data.codes[region.code] = append(data.codes[region.code], region)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, regions := range data.codes {
+		sort.SliceStable(regions, func(i, j int) bool { return regions[i].main && !regions[j].main })
+	}
+	return data, nil
+}
+
+func parsePhoneRegion(fields []string) (*phoneRegion, error) {
+	if len(fields) != 12 {
+		return nil, errors.New("expected 12 columns, got " + strconv.Itoa(len(fields)))
+	}
+	for i, f := range fields {
+		if f == "-" {
+			fields[i] = ""
+		}
+	}
+	r := &phoneRegion{region: fields[0], main: fields[2] == "main", transform: fields[6]}
+	var err error
+	if r.code, err = strconv.Atoi(fields[1]); err != nil || r.code <= 0 || r.code > 999 {
+		return nil, errors.New("invalid country calling code " + fields[1])
+	}
+	for _, l := range strings.Split(fields[8], ",") {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 || n > 17 {
+			return nil, errors.New("invalid length " + l)
+		}
+		r.lengths = append(r.lengths, n)
+	}
+	// The prefixes are matched at the start of the number, the number
+	// patterns match whole numbers.
+	patterns := []struct {
+		re     **regexp.Regexp
+		src    string
+		suffix string
+	}{
+		{&r.intl, fields[3], ""},
+		{&r.national, fields[5], ""},
+		{&r.leading, fields[7], ""},
+		{&r.general, fields[9], "$"},
+		{&r.fixed, fields[10], "$"},
+		{&r.mobile, fields[11], "$"},
+	}
+	for _, p := range patterns {
+		if p.src == "" {
+			continue
+		}
+		if *p.re, err = regexp.Compile("^(?:" + p.src + ")" + p.suffix); err != nil {
+			return nil, err
+		}
+	}
+	if r.general == nil {
+		return nil, errors.New("missing general pattern")
+	}
+	return r, nil
+}
+
+func phoneError(reason string) error {
+	return errors.New("invalid phone number: " + reason)
+}
+
+// ParsePhone parses a phone number in the international format, e.g.
+// "+44 20 7946 0958", or in the national format of defaultRegion, e.g.
+// "020 7946 0958" for "GB", and checks its length and prefixes with the
+// metadata of its region. The international prefix of defaultRegion, e.g.
+// "011 44 20 7946 0958" in the US, is accepted too. Spaces, dots, hyphens,
+// slashes and parentheses are ignored.
+func ParsePhone(str, defaultRegion string) (*Phone, error) {
+	data := loadPhoneData()
+	var digits strings.Builder
+	plus := false
+	for i, r := range strings.TrimSpace(str) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			plus = true
+		case r == ' ' || r == '-' || r == '.' || r == '/' || r == '(' || r == ')':
+		default:
+			return nil, phoneError("invalid character " + strconv.QuoteRune(r))
+		}
+	}
+	number := digits.String()
+	if len(number) < 2 {
+		return nil, phoneError("too short")
+	}
+	if len(number) > 17 {
+		return nil, phoneError("too long")
+	}
+
+	var region *phoneRegion
+	if defaultRegion != "" {
+		if region = data.regions[strings.ToUpper(defaultRegion)]; region == nil {
+			return nil, phoneError("unknown region " + defaultRegion)
+		}
+		if !plus && region.intl != nil {
+			if loc := region.intl.FindStringIndex(number); loc != nil {
+				plus, number = true, number[loc[1]:]
+			}
+		}
+	}
+
+	var code int
+	if plus {
+		for l := 1; l <= 3 && l < len(number); l++ {
+			n, _ := strconv.Atoi(number[:l])
+			if len(data.codes[n]) > 0 {
+				code, number = n, number[l:]
+				break
+			}
+		}
+		if code == 0 {
+			return nil, phoneError("unknown country calling code")
+		}
+		region = data.codes[code][0]
+	} else if region == nil {
+		return nil, phoneError("missing country calling code")
+	} else {
+		code = region.code
+	}
+	number = stripNationalPrefix(region, number)
+
+	region, typ := phoneRegionOf(data.codes[code], number)
+	if region == nil {
+		main := data.codes[code][0]
+		switch {
+		case len(number) < main.lengths[0]:
+			return nil, phoneError("too short for region " + main.region)
+		case len(number) > main.lengths[len(main.lengths)-1]:
+			return nil, phoneError("too long for region " + main.region)
+		}
+		return nil, phoneError("invalid number for country calling code " + strconv.Itoa(code))
+	}
+	return &Phone{CountryCode: code, NationalNumber: number, Region: region.region, Type: typ}, nil
+}
+
+// stripNationalPrefix removes the national prefix of a national number, or
+// replaces it as told by the transform rule, unless the number is only
+// valid with it.
+func stripNationalPrefix(region *phoneRegion, number string) string {
+	if region.national == nil {
+		return number
+	}
+	loc := region.national.FindStringSubmatchIndex(number)
+	if loc == nil {
+		return number
+	}
+	stripped := number[loc[1]:]
+	if region.transform != "" && len(loc) > 2 && loc[2] >= 0 {
+		stripped = string(region.national.ExpandString(nil, region.transform, number, loc)) + stripped
+	}
+	if stripped == "" || region.general.MatchString(number) && !region.general.MatchString(stripped) {
+		return number
+	}
+	return stripped
+}
+
+// phoneRegionOf returns the region of a national number among the regions
+// of its country calling code, and the number type. Regions are told apart
+// by their leading digits, or their fixed-line and mobile patterns.
+func phoneRegionOf(regions []*phoneRegion, number string) (*phoneRegion, PhoneType) {
+	var general *phoneRegion
+	for _, r := range regions {
+		if !r.general.MatchString(number) || !hasLength(r.lengths, len(number)) {
+			continue
+		}
+		typ := phoneTypeOf(r, number)
+		if typ != PhoneUnknown || r.leading != nil && r.leading.MatchString(number) {
+			return r, typ
+		}
+		if general == nil {
+			general = r
+		}
+	}
+	return general, PhoneUnknown
+}
+
+func phoneTypeOf(r *phoneRegion, number string) PhoneType {
+	fixed := r.fixed != nil && r.fixed.MatchString(number)
+	mobile := r.mobile != nil && r.mobile.MatchString(number)
+	switch {
+	case fixed && mobile:
+		return PhoneFixedLineOrMobile
+	case fixed:
+		return PhoneFixedLine
+	case mobile:
+		return PhoneMobile
+	}
+	return PhoneUnknown
+}
+
+func hasLength(lengths []int, n int) bool {
+	for _, l := range lengths {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
+
+// IsE164 checks if the string is a phone number in the E.164 format, a "+"
+// and up to 15 digits, e.g. "+442079460958". Use ParsePhone to check the
+// number against the numbering plan of its country.
+func IsE164(str string) bool {
+	if len(str) < 3 || len(str) > 16 || str[0] != '+' || str[1] == '0' {
+		return false
+	}
+	for i := 1; i < len(str); i++ {
+		if str[i] < '0' || str[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPhone checks if the string is a phone number, see ParsePhone.
+func IsPhone(str, defaultRegion string) bool {
+	_, err := ParsePhone(str, defaultRegion)
+	return err == nil
+}
+
+// ValidatePhone validate phone number, see ParsePhone. The number is
+// returned in the E.164 format.
+func (v *Validator) ValidatePhone(data interface{}, key, defaultRegion string) (string, error) {
+	val, err := v.checkExist(data, key, nil)
+	if err != nil {
+		return "", err
+	}
+	p, err := ParsePhone(val.(string), defaultRegion)
+	if err != nil {
+		return "", v.error(MsgRule, key, val.(string), "rule", "phone")
+	}
+	return p.E164(), nil
+}
+
+// ValidatePhonep validate phone number with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidatePhonep(data interface{}, key, defaultRegion string, code int, message string) string {
+	e164, err := v.ValidatePhone(data, key, defaultRegion)
+	if err != nil {
+		v.panicError(err, code, message)
+	}
+	return e164
+}
@@ -0,0 +1,114 @@
+package vvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePhone(t *testing.T) {
+	for _, tt := range []struct {
+		str    string
+		region string
+		e164   string
+		in     string
+		typ    PhoneType
+	}{
+		{"+1 415 555 2671", "", "+14155552671", "US", PhoneFixedLineOrMobile},
+		{"(415) 555-2671", "US", "+14155552671", "US", PhoneFixedLineOrMobile},
+		{"1-415-555-2671", "us", "+14155552671", "US", PhoneFixedLineOrMobile},
+		{"+1 613 555 0123", "", "+16135550123", "CA", PhoneFixedLineOrMobile},
+		{"613.555.0123", "US", "+16135550123", "CA", PhoneFixedLineOrMobile},
+		{"4601234", "AG", "+12684601234", "AG", PhoneFixedLine},
+		{"020 7946 0958", "GB", "+442079460958", "GB", PhoneFixedLine},
+		{"+44 (0)20 7946 0958", "", "+442079460958", "GB", PhoneFixedLine},
+		{"07400 123456", "GB", "+447400123456", "GB", PhoneMobile},
+		{"011 44 20 7946 0958", "US", "+442079460958", "GB", PhoneFixedLine},
+		{"00 1 415 555 2671", "GB", "+14155552671", "US", PhoneFixedLineOrMobile},
+		{"030 1234567", "DE", "+49301234567", "DE", PhoneFixedLine},
+		{"+49 1512 3456789", "", "+4915123456789", "DE", PhoneMobile},
+		{"+39 02 1234 5678", "", "+390212345678", "IT", PhoneFixedLine},
+		{"+39 312 345 6789", "", "+393123456789", "IT", PhoneMobile},
+		{"06 12 34 56 78", "FR", "+33612345678", "FR", PhoneMobile},
+		{"011 15-1234-5678", "AR", "+5491112345678", "AR", PhoneMobile},
+		{"+86 138 0013 8000", "", "+8613800138000", "CN", PhoneMobile},
+		{"+800 1234 5678", "", "+80012345678", "001", PhoneUnknown},
+	} {
+		p, err := ParsePhone(tt.str, tt.region)
+		if err != nil {
+			t.Errorf("%s: %v", tt.str, err)
+			continue
+		}
+		equal(t, tt.e164, p.E164())
+		equal(t, tt.in, p.Region)
+		equal(t, tt.typ, p.Type)
+	}
+
+	for _, tt := range []struct {
+		str    string
+		region string
+		err    string
+	}{
+		{"", "US", "too short"},
+		{"+1 415 CALL NOW", "", "invalid character 'C'"},
+		{"415+555+2671", "US", "invalid character '+'"},
+		{"4155552671", "", "missing country calling code"},
+		{"4155552671", "XX", "unknown region XX"},
+		{"4155552671", "001", "unknown region 001"},
+		{"+999 1234 5678", "", "unknown country calling code"},
+		{"555 2671", "US", "too short for region US"},
+		{"+1 415 555 26711", "", "too long for region US"},
+		{"+44 20 7946 09", "", "invalid number for country calling code 44"},
+		{"+" + strings.Repeat("1", 18), "", "too long"},
+	} {
+		_, err := ParsePhone(tt.str, tt.region)
+		if err == nil {
+			t.Errorf("%s: expected error %s", tt.str, tt.err)
+		} else {
+			equal(t, "invalid phone number: "+tt.err, err.Error())
+		}
+	}
+}
+
+func TestIsE164(t *testing.T) {
+	equal(t, true, IsE164("+14155552671"))
+	equal(t, true, IsE164("+442079460958"))
+	equal(t, false, IsE164("14155552671"))
+	equal(t, false, IsE164("+1 415 555 2671"))
+	equal(t, false, IsE164("+04155552671"))
+	equal(t, false, IsE164("+1234567890123456"))
+	equal(t, false, IsE164("+"))
+	equal(t, true, IsPhone("020 7946 0958", "GB"))
+	equal(t, false, IsPhone("020 7946 0958", ""))
+}
+
+func TestLoadPhoneMetadata(t *testing.T) {
+	defer func() {
+		equal(t, nil, LoadPhoneMetadata(strings.NewReader(phoneMetadataList)))
+	}()
+	equal(t, nil, LoadPhoneMetadata(strings.NewReader("# test\nZZ\t999\tmain\t00\t0\t0\t-\t-\t6\t[1-9]\\d{5}\t[1-5]\\d{5}\t[6-9]\\d{5}\n")))
+	p, err := ParsePhone("0 612345", "ZZ")
+	equal(t, nil, err)
+	equal(t, "+999612345", p.E164())
+	equal(t, PhoneMobile, p.Type)
+	_, err = ParsePhone("+44 20 7946 0958", "")
+	equal(t, "invalid phone number: unknown country calling code", err.Error())
+
+	err = LoadPhoneMetadata(strings.NewReader("ZZ\t999\n"))
+	equal(t, "phone metadata line 1: expected 12 columns, got 2", err.Error())
+	err = LoadPhoneMetadata(strings.NewReader("ZZ\t999\tmain\t00\t0\t0\t-\t-\t6\t[\t-\t-\n"))
+	equal(t, "phone metadata line 1: error parsing regexp: missing closing ]: `[)$`", err.Error())
+}
+
+func TestValidatePhone(t *testing.T) {
+	params := map[string]string{"phone": "(415) 555-2671", "bad": "555"}
+	e164, err := ValidatePhone(params, "phone", "US")
+	equal(t, nil, err)
+	equal(t, "+14155552671", e164)
+	_, err = ValidatePhone(params, "bad", "US")
+	equal(t, "bad must be a valid phone", err.Error())
+	_, err = ValidatePhone(params, "missing", "US")
+	equal(t, "missing is required", err.Error())
+
+	equal(t, nil, ValidateRule(params, "phone", "required|phone:US"))
+	equal(t, "bad must be a valid phone", ValidateRule(params, "bad", "phone:US").Error())
+}
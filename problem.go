@@ -0,0 +1,75 @@
+package vvalidator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem documents.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document.
+type Problem struct {
+	Type          string         `json:"type,omitempty"`
+	Title         string         `json:"title,omitempty"`
+	Status        int            `json:"status,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam is an entry of the "invalid-params" extension of Problem.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// NewProblem returns the problem document of err, an Error, Errors or any
+// other error, wrapped ones included. The status is the error Code if it's
+// an HTTP status, otherwise 400 Bad Request; the type is "about:blank" so the
+// title is the status text. The detail is the reasons of the errors, their
+// CustomMessage if set, separated by "; ". A nil err has no detail.
+func NewProblem(err error) *Problem {
+	p := &Problem{
+		Type:   "about:blank",
+		Status: http.StatusBadRequest,
+	}
+
+	var es Errors
+	var e Error
+	switch {
+	case errors.As(err, &es):
+	case errors.As(err, &e):
+		es = Errors{e}
+	case err != nil:
+		p.Detail = err.Error()
+	}
+	reasons := make([]string, len(es))
+	for i, e := range es {
+		reasons[i] = e.Message
+		if e.CustomMessage != "" {
+			reasons[i] = e.CustomMessage
+		}
+		if i == 0 && e.Code >= 100 && e.Code <= 599 {
+			p.Status = e.Code
+		}
+		if e.Field != "" {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: e.Field, Reason: reasons[i]})
+		}
+	}
+	if len(es) > 0 {
+		p.Detail = strings.Join(reasons, "; ")
+	}
+	p.Title = http.StatusText(p.Status)
+	return p
+}
+
+// WriteProblem writes the problem document of err as the response.
+func WriteProblem(w http.ResponseWriter, err error) error {
+	p := NewProblem(err)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,54 @@
+package vvalidator
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProblem(t *testing.T) {
+	params := map[string]string{
+		"uid":   "123",
+		"email": "a@b",
+	}
+
+	err := ValidateRules(params, map[string]string{"uid": "int|max:10", "email": "email|ipv4", "name": "required"})
+	equal(t, &Problem{
+		Type:   "about:blank",
+		Title:  "Bad Request",
+		Status: 400,
+		Detail: "email must be a valid ipv4; name is required; uid is too big (maximum is 10)",
+		InvalidParams: []InvalidParam{
+			{Name: "email", Reason: "email must be a valid ipv4"},
+			{Name: "name", Reason: "name is required"},
+			{Name: "uid", Reason: "uid is too big (maximum is 10)"},
+		},
+	}, NewProblem(err))
+
+	v := New()
+	v.Code = 422
+	_, err = v.ValidateInt(params, "uid", 0, 10)
+	w := httptest.NewRecorder()
+	WriteProblem(w, err)
+	equal(t, 422, w.Code)
+	equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	equal(t, `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"uid is too big (maximum is 10)","invalid-params":[{"name":"uid","reason":"uid is too big (maximum is 10)"}]}`+"\n", w.Body.String())
+
+	equal(t, &Problem{Type: "about:blank", Title: "Bad Request", Status: 400, Detail: "boom"}, NewProblem(errors.New("boom")))
+	equal(t, &Problem{Type: "about:blank", Title: "Bad Request", Status: 400}, NewProblem(nil))
+
+	p := NewProblem(fmt.Errorf("create user: %w", err))
+	equal(t, 422, p.Status)
+	equal(t, "uid is too big (maximum is 10)", p.Detail)
+	p = NewProblem(fmt.Errorf("create user: %w", ValidateRules(params, map[string]string{"uid": "int|max:10", "name": "required"})))
+	equal(t, 2, len(p.InvalidParams))
+
+	// the detail uses the same reasons as the invalid params
+	p = NewProblem(Errors{
+		{Message: "name is required", Field: "name", CustomMessage: "Please enter your name"},
+		{Message: "uid is too big (maximum is 10)", Field: "uid"},
+	})
+	equal(t, "Please enter your name; uid is too big (maximum is 10)", p.Detail)
+	equal(t, "Please enter your name", p.InvalidParams[0].Reason)
+}
@@ -0,0 +1,342 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: protovalidator/internal/testpb/test.proto
+
+package testpb
+
+import (
+	_ "github.com/syyongx/vvalidator/protovalidator"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_protovalidator_internal_testpb_test_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_protovalidator_internal_testpb_test_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_protovalidator_internal_testpb_test_proto_rawDescGZIP(), []int{0}
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         *string                `protobuf:"bytes,2,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Age           int32                  `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
+	Status        Status                 `protobuf:"varint,5,opt,name=status,proto3,enum=vvalidator.test.Status" json:"status,omitempty"`
+	Addresses     []*Address             `protobuf:"bytes,6,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Labeled       map[string]*Address    `protobuf:"bytes,7,rep,name=labeled,proto3" json:"labeled,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Score         *float64               `protobuf:"fixed64,8,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	Qty           int32                  `protobuf:"varint,9,opt,name=qty,proto3" json:"qty,omitempty"`
+	Serial        int64                  `protobuf:"varint,10,opt,name=serial,proto3" json:"serial,omitempty"`
+	Counter       uint64                 `protobuf:"varint,11,opt,name=counter,proto3" json:"counter,omitempty"`
+	Tags          []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	Ref           string                 `protobuf:"bytes,13,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_protovalidator_internal_testpb_test_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_protovalidator_internal_testpb_test_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_protovalidator_internal_testpb_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetAge() int32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+func (x *User) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *User) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *User) GetLabeled() map[string]*Address {
+	if x != nil {
+		return x.Labeled
+	}
+	return nil
+}
+
+func (x *User) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *User) GetQty() int32 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *User) GetSerial() int64 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+func (x *User) GetCounter() uint64 {
+	if x != nil {
+		return x.Counter
+	}
+	return 0
+}
+
+func (x *User) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *User) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_protovalidator_internal_testpb_test_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_protovalidator_internal_testpb_test_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_protovalidator_internal_testpb_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+var File_protovalidator_internal_testpb_test_proto protoreflect.FileDescriptor
+
+const file_protovalidator_internal_testpb_test_proto_rawDesc = "" +
+	"\n" +
+	")protovalidator/internal/testpb/test.proto\x12\x0fvvalidator.test\x1a\x1fprotovalidator/vvalidator.proto\"\x87\x05\n" +
+	"\x04User\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\tB\b\x92\xf9\x18\x04\b\x01@\x01R\x02id\x12!\n" +
+	"\x05email\x18\x02 \x01(\tB\x06\x92\xf9\x18\x028\x01H\x00R\x05email\x88\x01\x01\x12&\n" +
+	"\x04name\x18\x03 \x01(\tB\x12\x92\xf9\x18\x0e \x01(\b2\b^[a-z]+$R\x04name\x12(\n" +
+	"\x03age\x18\x04 \x01(\x05B\x16\x92\xf9\x18\x12\x11\x00\x00\x00\x00\x00\x00\x00\x00\x19\x00\x00\x00\x00\x00\xc0b@R\x03age\x127\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x17.vvalidator.test.StatusB\x06\x92\xf9\x18\x02H\x01R\x06status\x12>\n" +
+	"\taddresses\x18\x06 \x03(\v2\x18.vvalidator.test.AddressB\x06\x92\xf9\x18\x02(\x02R\taddresses\x12<\n" +
+	"\alabeled\x18\a \x03(\v2\".vvalidator.test.User.LabeledEntryR\alabeled\x12(\n" +
+	"\x05score\x18\b \x01(\x01B\r\x92\xf9\x18\t\x11\x00\x00\x00\x00\x00\x00\xe0?H\x01R\x05score\x88\x01\x01\x12\x1f\n" +
+	"\x03qty\x18\t \x01(\x05B\r\x92\xf9\x18\t\x11\x00\x00\x00\x00\x00\x00\xf0?R\x03qty\x12%\n" +
+	"\x06serial\x18\n" +
+	" \x01(\x03B\r\x92\xf9\x18\t\x19\x00\x00\x00\x00\x00\x00@CR\x06serial\x12'\n" +
+	"\acounter\x18\v \x01(\x04B\r\x92\xf9\x18\t\x19\x00\x00\x00\x00\x00\x00@CR\acounter\x12\x1a\n" +
+	"\x04tags\x18\f \x03(\tB\x06\x92\xf9\x18\x02 \x01R\x04tags\x12\x18\n" +
+	"\x03ref\x18\r \x01(\tB\x06\x92\xf9\x18\x02@\x01R\x03ref\x1aT\n" +
+	"\fLabeledEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.vvalidator.test.AddressR\x05value:\x028\x01B\b\n" +
+	"\x06_emailB\b\n" +
+	"\x06_score\"%\n" +
+	"\aAddress\x12\x1a\n" +
+	"\x04city\x18\x01 \x01(\tB\x06\x92\xf9\x18\x02\b\x01R\x04city*3\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATUS_ACTIVE\x10\x01B>Z<github.com/syyongx/vvalidator/protovalidator/internal/testpbb\x06proto3"
+
+var (
+	file_protovalidator_internal_testpb_test_proto_rawDescOnce sync.Once
+	file_protovalidator_internal_testpb_test_proto_rawDescData []byte
+)
+
+func file_protovalidator_internal_testpb_test_proto_rawDescGZIP() []byte {
+	file_protovalidator_internal_testpb_test_proto_rawDescOnce.Do(func() {
+		file_protovalidator_internal_testpb_test_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protovalidator_internal_testpb_test_proto_rawDesc), len(file_protovalidator_internal_testpb_test_proto_rawDesc)))
+	})
+	return file_protovalidator_internal_testpb_test_proto_rawDescData
+}
+
+var file_protovalidator_internal_testpb_test_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_protovalidator_internal_testpb_test_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_protovalidator_internal_testpb_test_proto_goTypes = []any{
+	(Status)(0),     // 0: vvalidator.test.Status
+	(*User)(nil),    // 1: vvalidator.test.User
+	(*Address)(nil), // 2: vvalidator.test.Address
+	nil,             // 3: vvalidator.test.User.LabeledEntry
+}
+var file_protovalidator_internal_testpb_test_proto_depIdxs = []int32{
+	0, // 0: vvalidator.test.User.status:type_name -> vvalidator.test.Status
+	2, // 1: vvalidator.test.User.addresses:type_name -> vvalidator.test.Address
+	3, // 2: vvalidator.test.User.labeled:type_name -> vvalidator.test.User.LabeledEntry
+	2, // 3: vvalidator.test.User.LabeledEntry.value:type_name -> vvalidator.test.Address
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_protovalidator_internal_testpb_test_proto_init() }
+func file_protovalidator_internal_testpb_test_proto_init() {
+	if File_protovalidator_internal_testpb_test_proto != nil {
+		return
+	}
+	file_protovalidator_internal_testpb_test_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protovalidator_internal_testpb_test_proto_rawDesc), len(file_protovalidator_internal_testpb_test_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protovalidator_internal_testpb_test_proto_goTypes,
+		DependencyIndexes: file_protovalidator_internal_testpb_test_proto_depIdxs,
+		EnumInfos:         file_protovalidator_internal_testpb_test_proto_enumTypes,
+		MessageInfos:      file_protovalidator_internal_testpb_test_proto_msgTypes,
+	}.Build()
+	File_protovalidator_internal_testpb_test_proto = out.File
+	file_protovalidator_internal_testpb_test_proto_goTypes = nil
+	file_protovalidator_internal_testpb_test_proto_depIdxs = nil
+}
@@ -0,0 +1,319 @@
+// Package protovalidator validates protobuf messages with the rules declared
+// in their field options:
+//
+//	import "protovalidator/vvalidator.proto";
+//
+//	message User {
+//	  string id = 1 [(vvalidator.rules) = {required: true, uuid: true}];
+//	  int32 age = 2 [(vvalidator.rules) = {min: 0, max: 150}];
+//	}
+//
+// Messages are walked with protoreflect, nested messages, lists and maps
+// included, and failures are reported as vvalidator.Errors whose Path locates
+// the field, e.g. "addresses[0].city".
+package protovalidator
+
+//go:generate protoc -I .. --go_out=.. --go_opt=module=github.com/syyongx/vvalidator protovalidator/vvalidator.proto protovalidator/internal/testpb/test.proto
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/syyongx/vvalidator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Validator validates protobuf messages, reporting errors with the messages
+// of a vvalidator.Validator.
+type Validator struct {
+	v *vvalidator.Validator
+}
+
+// New returns a validator using v for error messages, nil means vvalidator.Default().
+func New(v *vvalidator.Validator) *Validator {
+	if v == nil {
+		v = vvalidator.Default()
+	}
+	return &Validator{v: v}
+}
+
+// Validate validates m with the default validator.
+func Validate(m proto.Message) error {
+	return New(nil).Validate(m)
+}
+
+// Validate validates m and its nested messages, the validation errors of all
+// fields are returned as vvalidator.Errors.
+func (pv *Validator) Validate(m proto.Message) error {
+	w := &walker{v: pv.v}
+	w.message(m.ProtoReflect(), "")
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.errs) > 0 {
+		return w.errs
+	}
+	return nil
+}
+
+var patterns sync.Map
+
+// walker collects the errors of a message tree, err is set on invalid rules.
+type walker struct {
+	v    *vvalidator.Validator
+	errs vvalidator.Errors
+	err  error
+}
+
+func (w *walker) message(m protoreflect.Message, prefix string) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len() && w.err == nil; i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		if r := fieldRules(fd); r != nil {
+			w.field(m, fd, r, path)
+		}
+		if fd.Message() == nil || !m.Has(fd) {
+			continue
+		}
+
+		val := m.Get(fd)
+		switch {
+		case fd.IsList():
+			list := val.List()
+			for j := 0; j < list.Len(); j++ {
+				w.message(list.Get(j).Message(), path+"["+strconv.Itoa(j)+"].")
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			mp := val.Map()
+			for _, k := range sortedKeys(mp) {
+				w.message(mp.Get(k).Message(), path+"["+strconv.Quote(k.String())+"].")
+			}
+		default:
+			w.message(val.Message(), path+".")
+		}
+	}
+}
+
+func fieldRules(fd protoreflect.FieldDescriptor) *FieldRules {
+	opts := fd.Options()
+	if opts == nil || !proto.HasExtension(opts, E_Rules) {
+		return nil
+	}
+	return proto.GetExtension(opts, E_Rules).(*FieldRules)
+}
+
+func sortedKeys(mp protoreflect.Map) []protoreflect.MapKey {
+	var keys []protoreflect.MapKey
+	mp.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+func (w *walker) field(m protoreflect.Message, fd protoreflect.FieldDescriptor, r *FieldRules, path string) {
+	// Fields without presence, e.g. proto3 scalars, can't tell unset from
+	// zero, every rule checks their zero value. Fields with presence, e.g.
+	// proto3 optional ones, are only checked if set.
+	if !m.Has(fd) {
+		if r.GetRequired() {
+			w.fail(vvalidator.MsgRequired, fd, path, "")
+			return
+		}
+		if fd.HasPresence() {
+			return
+		}
+	}
+
+	val := m.Get(fd)
+	switch {
+	case fd.IsList():
+		list := val.List()
+		w.count(fd, r, path, list.Len())
+		for j := 0; j < list.Len() && fd.Message() == nil; j++ {
+			w.scalar(fd, r, path+"["+strconv.Itoa(j)+"]", list.Get(j))
+		}
+	case fd.IsMap():
+		w.count(fd, r, path, val.Map().Len())
+	case fd.Message() == nil:
+		if fd.Kind() == protoreflect.StringKind {
+			w.length(fd, r, path, val.String())
+		}
+		w.scalar(fd, r, path, val)
+	}
+}
+
+// count checks the number of list or map entries.
+func (w *walker) count(fd protoreflect.FieldDescriptor, r *FieldRules, path string, n int) {
+	value := strconv.Itoa(n)
+	if r.MinLen != nil && uint64(n) < r.GetMinLen() {
+		w.fail(vvalidator.MsgMinItems, fd, path, value, lenBounds(r)...)
+	}
+	if r.MaxLen != nil && uint64(n) > r.GetMaxLen() {
+		w.fail(vvalidator.MsgMaxItems, fd, path, value, lenBounds(r)...)
+	}
+}
+
+// length checks the number of characters of a string.
+func (w *walker) length(fd protoreflect.FieldDescriptor, r *FieldRules, path string, s string) {
+	n := uint64(utf8.RuneCountInString(s))
+	if r.MinLen != nil && n < r.GetMinLen() {
+		w.fail(vvalidator.MsgMinLength, fd, path, s, lenBounds(r)...)
+	}
+	if r.MaxLen != nil && n > r.GetMaxLen() {
+		w.fail(vvalidator.MsgMaxLength, fd, path, s, lenBounds(r)...)
+	}
+}
+
+// scalar checks a single value of a field.
+func (w *walker) scalar(fd protoreflect.FieldDescriptor, r *FieldRules, path string, val protoreflect.Value) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		s := val.String()
+		if r.GetPattern() != "" {
+			re, err := compile(r.GetPattern())
+			if err != nil {
+				w.err = err
+				return
+			}
+			if !re.MatchString(s) {
+				w.fail(vvalidator.MsgPattern, fd, path, s)
+			}
+		}
+		if r.GetEmail() && !vvalidator.IsEmail(s) {
+			w.fail(vvalidator.MsgRule, fd, path, s, "rule", "email")
+		}
+		if r.GetUuid() && !vvalidator.IsUUID(s) {
+			w.fail(vvalidator.MsgRule, fd, path, s, "rule", "uuid")
+		}
+	case protoreflect.EnumKind:
+		if r.GetDefinedOnly() && fd.Enum().Values().ByNumber(val.Enum()) == nil {
+			w.fail(vvalidator.MsgInvalid, fd, path, strconv.Itoa(int(val.Enum())))
+		}
+	case protoreflect.BoolKind, protoreflect.BytesKind:
+	default:
+		value, below, above := compareBounds(fd.Kind(), val, r)
+		if below {
+			w.fail(vvalidator.MsgMin, fd, path, value, bounds(r)...)
+		}
+		if above {
+			w.fail(vvalidator.MsgMax, fd, path, value, bounds(r)...)
+		}
+	}
+}
+
+// compareBounds formats a number and reports whether it's below the minimum
+// or above the maximum. Integers are compared as integers, so that 64-bit
+// values don't lose precision as float64.
+func compareBounds(kind protoreflect.Kind, val protoreflect.Value, r *FieldRules) (value string, below, above bool) {
+	switch kind {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := val.Float()
+		bits := 64
+		if kind == protoreflect.FloatKind {
+			bits = 32
+		}
+		return strconv.FormatFloat(f, 'g', -1, bits), r.Min != nil && f < r.GetMin(), r.Max != nil && f > r.GetMax()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u := val.Uint()
+		return strconv.FormatUint(u, 10), r.Min != nil && cmpUint(u, r.GetMin()) < 0, r.Max != nil && cmpUint(u, r.GetMax()) > 0
+	}
+	n := val.Int()
+	return strconv.FormatInt(n, 10), r.Min != nil && cmpInt(n, r.GetMin()) < 0, r.Max != nil && cmpInt(n, r.GetMax()) > 0
+}
+
+// cmpInt compares an integer with a float64 bound exactly.
+func cmpInt(n int64, f float64) int {
+	switch {
+	case math.IsNaN(f):
+		return 0
+	case f >= 1<<63:
+		return -1
+	case f < -1<<63:
+		return 1
+	}
+	i := int64(f)
+	switch {
+	case n < i:
+		return -1
+	case n > i:
+		return 1
+	case float64(i) < f: // n == trunc(f) < f
+		return -1
+	case float64(i) > f:
+		return 1
+	}
+	return 0
+}
+
+// cmpUint compares an unsigned integer with a float64 bound exactly.
+func cmpUint(n uint64, f float64) int {
+	switch {
+	case math.IsNaN(f):
+		return 0
+	case f < 0:
+		return 1
+	case f >= 1<<64:
+		return -1
+	}
+	u := uint64(f)
+	switch {
+	case n < u:
+		return -1
+	case n > u:
+		return 1
+	case float64(u) < f:
+		return -1
+	}
+	return 0
+}
+
+func (w *walker) fail(id string, fd protoreflect.FieldDescriptor, path, value string, params ...string) {
+	e := w.v.FieldError(id, string(fd.Name()), value, params...)
+	e.Path = path
+	w.errs = append(w.errs, e)
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+func bounds(r *FieldRules) []string {
+	var params []string
+	if r.Min != nil {
+		params = append(params, "min", strconv.FormatFloat(r.GetMin(), 'f', -1, 64))
+	}
+	if r.Max != nil {
+		params = append(params, "max", strconv.FormatFloat(r.GetMax(), 'f', -1, 64))
+	}
+	return params
+}
+
+func lenBounds(r *FieldRules) []string {
+	var params []string
+	if r.MinLen != nil {
+		params = append(params, "min", strconv.FormatUint(r.GetMinLen(), 10))
+	}
+	if r.MaxLen != nil {
+		params = append(params, "max", strconv.FormatUint(r.GetMaxLen(), 10))
+	}
+	return params
+}
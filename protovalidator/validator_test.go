@@ -0,0 +1,90 @@
+package protovalidator_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/syyongx/vvalidator"
+	"github.com/syyongx/vvalidator/protovalidator"
+	"github.com/syyongx/vvalidator/protovalidator/internal/testpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidate(t *testing.T) {
+	user := &testpb.User{
+		Id:     "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		Email:  proto.String("a@b.com"),
+		Name:   "feng",
+		Age:    20,
+		Status: testpb.Status_STATUS_ACTIVE,
+		Addresses: []*testpb.Address{
+			{City: "beijing"},
+		},
+		Qty:     1,
+		Serial:  1 << 53,
+		Counter: 1 << 53,
+		Tags:    []string{"a"},
+		Ref:     "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	}
+	equal(t, nil, protovalidator.Validate(user))
+
+	user = &testpb.User{
+		Email:     proto.String("a@"),
+		Name:      "Fengmotiii",
+		Age:       200,
+		Status:    testpb.Status(7),
+		Addresses: []*testpb.Address{{City: "beijing"}, {}, {}},
+		Labeled:   map[string]*testpb.Address{"home": {}},
+		Score:     proto.Float64(0.1),
+		Serial:    1<<53 + 1,
+		Counter:   1<<53 + 1,
+		Ref:       "x",
+	}
+	err := protovalidator.Validate(user)
+	var paths, msgs []string
+	for _, e := range err.(vvalidator.Errors) {
+		paths = append(paths, e.Path)
+		msgs = append(msgs, e.Message)
+	}
+	equal(t, []string{"id", "email", "name", "name", "age", "status", "addresses", "addresses[1].city", "addresses[2].city", "labeled[\"home\"].city", "score", "qty", "serial", "counter", "tags", "ref"}, paths)
+	equal(t, []string{
+		"id is required",
+		"email must be a valid email",
+		"name is too long (maximum is 8 characters)",
+		"name must be a valid string",
+		"age is too big (maximum is 150)",
+		"status is invalid",
+		"addresses is too long (maximum is 2 elements)",
+		"city is required",
+		"city is required",
+		"city is required",
+		"score is too small (minimum is 0.5)",
+		"qty is too small (minimum is 1)",
+		"serial is too big (maximum is 9007199254740992)",
+		"counter is too big (maximum is 9007199254740992)",
+		"tags is too short (minimum is 1 elements)",
+		"ref must be a valid uuid",
+	}, msgs)
+
+	v := vvalidator.New()
+	v.Labels["age"] = "Age"
+	err = protovalidator.New(v).Validate(&testpb.User{Id: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", Name: "feng", Age: -1, Qty: 1, Tags: []string{"a"}, Ref: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"})
+	equal(t, "Age is too small (minimum is 0)", err.Error())
+
+	// Implicit presence fields are checked at their zero value by every rule,
+	// fields with presence only if set.
+	err = protovalidator.Validate(&testpb.User{Id: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"})
+	paths = nil
+	for _, e := range err.(vvalidator.Errors) {
+		paths = append(paths, e.Path)
+	}
+	equal(t, []string{"name", "name", "qty", "tags", "ref"}, paths)
+}
+
+// Expected to be equal.
+func equal(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v (type %v) - Got %v (type %v)", expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+	}
+}
@@ -0,0 +1,246 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: protovalidator/vvalidator.proto
+
+package protovalidator
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldRules are the validation rules of a field, e.g.
+//
+//	string email = 1 [(vvalidator.rules) = {required: true, email: true}];
+//	int32 age = 2 [(vvalidator.rules) = {min: 0, max: 150}];
+type FieldRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The field must be set: non-zero scalars, set messages, non-empty lists and maps.
+	Required bool `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	// Minimum of numbers.
+	Min *float64 `protobuf:"fixed64,2,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// Maximum of numbers.
+	Max *float64 `protobuf:"fixed64,3,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	// Minimum length of strings in characters, or number of list and map entries.
+	MinLen *uint64 `protobuf:"varint,4,opt,name=min_len,json=minLen,proto3,oneof" json:"min_len,omitempty"`
+	// Maximum length of strings in characters, or number of list and map entries.
+	MaxLen *uint64 `protobuf:"varint,5,opt,name=max_len,json=maxLen,proto3,oneof" json:"max_len,omitempty"`
+	// Regular expression strings must match.
+	Pattern string `protobuf:"bytes,6,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Strings must be email addresses.
+	Email bool `protobuf:"varint,7,opt,name=email,proto3" json:"email,omitempty"`
+	// Strings must be UUIDs.
+	Uuid bool `protobuf:"varint,8,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Enums must be one of the defined values.
+	DefinedOnly   bool `protobuf:"varint,9,opt,name=defined_only,json=definedOnly,proto3" json:"defined_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldRules) Reset() {
+	*x = FieldRules{}
+	mi := &file_protovalidator_vvalidator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldRules) ProtoMessage() {}
+
+func (x *FieldRules) ProtoReflect() protoreflect.Message {
+	mi := &file_protovalidator_vvalidator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldRules.ProtoReflect.Descriptor instead.
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return file_protovalidator_vvalidator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldRules) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *FieldRules) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMinLen() uint64 {
+	if x != nil && x.MinLen != nil {
+		return *x.MinLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMaxLen() uint64 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *FieldRules) GetEmail() bool {
+	if x != nil {
+		return x.Email
+	}
+	return false
+}
+
+func (x *FieldRules) GetUuid() bool {
+	if x != nil {
+		return x.Uuid
+	}
+	return false
+}
+
+func (x *FieldRules) GetDefinedOnly() bool {
+	if x != nil {
+		return x.DefinedOnly
+	}
+	return false
+}
+
+var file_protovalidator_vvalidator_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldRules)(nil),
+		Field:         51090,
+		Name:          "vvalidator.rules",
+		Tag:           "bytes,51090,opt,name=rules",
+		Filename:      "protovalidator/vvalidator.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// 51090 is in the 50000-99999 range that protobuf leaves to in-house
+	// options, it is not in the global extension registry
+	// (https://github.com/protocolbuffers/protobuf/blob/main/docs/options.md).
+	// It must not be used by another FieldOptions extension linked into the
+	// same program, protoc and the Go runtime reject the conflict. The number
+	// is part of the wire format of the options and won't change.
+	//
+	// optional vvalidator.FieldRules rules = 51090;
+	E_Rules = &file_protovalidator_vvalidator_proto_extTypes[0]
+)
+
+var File_protovalidator_vvalidator_proto protoreflect.FileDescriptor
+
+const file_protovalidator_vvalidator_proto_rawDesc = "" +
+	"\n" +
+	"\x1fprotovalidator/vvalidator.proto\x12\n" +
+	"vvalidator\x1a google/protobuf/descriptor.proto\"\xa1\x02\n" +
+	"\n" +
+	"FieldRules\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\bR\brequired\x12\x15\n" +
+	"\x03min\x18\x02 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x03 \x01(\x01H\x01R\x03max\x88\x01\x01\x12\x1c\n" +
+	"\amin_len\x18\x04 \x01(\x04H\x02R\x06minLen\x88\x01\x01\x12\x1c\n" +
+	"\amax_len\x18\x05 \x01(\x04H\x03R\x06maxLen\x88\x01\x01\x12\x18\n" +
+	"\apattern\x18\x06 \x01(\tR\apattern\x12\x14\n" +
+	"\x05email\x18\a \x01(\bR\x05email\x12\x12\n" +
+	"\x04uuid\x18\b \x01(\bR\x04uuid\x12!\n" +
+	"\fdefined_only\x18\t \x01(\bR\vdefinedOnlyB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_maxB\n" +
+	"\n" +
+	"\b_min_lenB\n" +
+	"\n" +
+	"\b_max_len:M\n" +
+	"\x05rules\x12\x1d.google.protobuf.FieldOptions\x18\x92\x8f\x03 \x01(\v2\x16.vvalidator.FieldRulesR\x05rulesB.Z,github.com/syyongx/vvalidator/protovalidatorb\x06proto3"
+
+var (
+	file_protovalidator_vvalidator_proto_rawDescOnce sync.Once
+	file_protovalidator_vvalidator_proto_rawDescData []byte
+)
+
+func file_protovalidator_vvalidator_proto_rawDescGZIP() []byte {
+	file_protovalidator_vvalidator_proto_rawDescOnce.Do(func() {
+		file_protovalidator_vvalidator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protovalidator_vvalidator_proto_rawDesc), len(file_protovalidator_vvalidator_proto_rawDesc)))
+	})
+	return file_protovalidator_vvalidator_proto_rawDescData
+}
+
+var file_protovalidator_vvalidator_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protovalidator_vvalidator_proto_goTypes = []any{
+	(*FieldRules)(nil),                // 0: vvalidator.FieldRules
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_protovalidator_vvalidator_proto_depIdxs = []int32{
+	1, // 0: vvalidator.rules:extendee -> google.protobuf.FieldOptions
+	0, // 1: vvalidator.rules:type_name -> vvalidator.FieldRules
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protovalidator_vvalidator_proto_init() }
+func file_protovalidator_vvalidator_proto_init() {
+	if File_protovalidator_vvalidator_proto != nil {
+		return
+	}
+	file_protovalidator_vvalidator_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protovalidator_vvalidator_proto_rawDesc), len(file_protovalidator_vvalidator_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_protovalidator_vvalidator_proto_goTypes,
+		DependencyIndexes: file_protovalidator_vvalidator_proto_depIdxs,
+		MessageInfos:      file_protovalidator_vvalidator_proto_msgTypes,
+		ExtensionInfos:    file_protovalidator_vvalidator_proto_extTypes,
+	}.Build()
+	File_protovalidator_vvalidator_proto = out.File
+	file_protovalidator_vvalidator_proto_goTypes = nil
+	file_protovalidator_vvalidator_proto_depIdxs = nil
+}
@@ -0,0 +1,256 @@
+package vvalidator
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+// public_suffix_list.dat is a snapshot of https://publicsuffix.org/list/,
+// subject to the Mozilla Public License 2.0.
+//
+//go:embed public_suffix_list.dat
+var publicSuffixList string
+
+// Kinds of public suffix rules, see https://publicsuffix.org/list/.
+const (
+	psNormal    = 1 << iota // "co.uk"
+	psWildcard              // "*.ck", keyed by "ck"
+	psException             // "!www.ck", keyed by "www.ck"
+)
+
+// psRule holds the kinds of the rules of a name, private are the kinds
+// from the private domains section of the list.
+type psRule struct {
+	kinds, private uint8
+}
+
+var publicSuffixes struct {
+	once  sync.Once
+	mu    sync.RWMutex
+	rules map[string]psRule
+}
+
+// loadPublicSuffixes parses the built-in list on first use.
+func loadPublicSuffixes() {
+	publicSuffixes.once.Do(func() {
+		rules, err := parsePublicSuffixList(strings.NewReader(publicSuffixList))
+		if err != nil {
+			panic(err)
+		}
+		publicSuffixes.mu.Lock()
+		publicSuffixes.rules = rules
+		publicSuffixes.mu.Unlock()
+	})
+}
+
+// LoadPublicSuffixList replaces the public suffixes with the list read from
+// r, in the format of https://publicsuffix.org/list/public_suffix_list.dat.
+// The built-in list is a snapshot, load a current one to keep up with new
+// top-level domains and suffixes.
+func LoadPublicSuffixList(r io.Reader) error {
+	rules, err := parsePublicSuffixList(r)
+	if err != nil {
+		return err
+	}
+	loadPublicSuffixes()
+	publicSuffixes.mu.Lock()
+	publicSuffixes.rules = rules
+	publicSuffixes.mu.Unlock()
+	return nil
+}
+
+func parsePublicSuffixList(r io.Reader) (map[string]psRule, error) {
+	rules := make(map[string]psRule)
+	private := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "// ===BEGIN PRIVATE DOMAINS==="):
+			private = true
+			continue
+		case strings.HasPrefix(line, "// ===END PRIVATE DOMAINS==="):
+			private = false
+			continue
+		case line == "" || strings.HasPrefix(line, "//"):
+			continue
+		}
+		// Rules end at the first white space.
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			line = line[:i]
+		}
+		kind := uint8(psNormal)
+		switch {
+		case strings.HasPrefix(line, "!"):
+			kind, line = psException, line[1:]
+		case strings.HasPrefix(line, "*."):
+			kind, line = psWildcard, line[2:]
+		}
+		name, err := asciiDomain(line, true)
+		if err != nil {
+			return nil, errors.New("invalid public suffix rule " + line + ": " + err.Error())
+		}
+		name = strings.ToLower(name)
+		rule := rules[name]
+		rule.kinds |= kind
+		if private {
+			rule.private |= kind
+		}
+		rules[name] = rule
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// PublicSuffix returns the public suffix of a domain, e.g. "co.uk" for
+// "www.example.co.uk", and whether it's an ICANN suffix rather than one of
+// the private domains of the list, e.g. "github.io". Domains under unknown
+// top-level domains have their top-level domain as suffix, not ICANN.
+func PublicSuffix(domain string) (suffix string, icann bool) {
+	name := domainKey(domain)
+	n, icann := publicSuffixLabels(name, false)
+	labels := strings.Split(name, ".")
+	return strings.Join(labels[len(labels)-n:], "."), icann
+}
+
+// publicSuffixLabels returns the number of labels of the public suffix of
+// a lowercase ASCII domain, following the prevailing rule of the list.
+func publicSuffixLabels(domain string, icannOnly bool) (int, bool) {
+	loadPublicSuffixes()
+	publicSuffixes.mu.RLock()
+	defer publicSuffixes.mu.RUnlock()
+	rules := publicSuffixes.rules
+	match := func(name string, kind uint8) (bool, bool) {
+		rule, ok := rules[name]
+		if !ok || rule.kinds&kind == 0 || icannOnly && rule.private&kind != 0 {
+			return false, false
+		}
+		return true, rule.private&kind == 0
+	}
+
+	labels := strings.Split(domain, ".")
+	for i := range labels {
+		name := strings.Join(labels[i:], ".")
+		if ok, icann := match(name, psException); ok {
+			return len(labels) - i - 1, icann
+		}
+		if ok, icann := match(name, psNormal); ok {
+			return len(labels) - i, icann
+		}
+		if i+1 < len(labels) {
+			if ok, icann := match(strings.Join(labels[i+1:], "."), psWildcard); ok {
+				return len(labels) - i, icann
+			}
+		}
+	}
+	// The default rule "*": the top-level domain is the suffix.
+	return 1, false
+}
+
+// EffectiveTLDPlusOne returns the registrable domain of a domain, its
+// public suffix plus one label, e.g. "example.co.uk" for
+// "www.example.co.uk". Public suffixes have none, e.g. "co.uk".
+func EffectiveTLDPlusOne(domain string) (string, error) {
+	name := domainKey(domain)
+	labels := strings.Split(name, ".")
+	n, _ := publicSuffixLabels(name, false)
+	if n >= len(labels) {
+		return "", errors.New("domain " + domain + " is a public suffix")
+	}
+	return strings.Join(labels[len(labels)-n-1:], "."), nil
+}
+
+// IsPublicSuffix checks if the domain is a public suffix, e.g. "com",
+// "co.uk" or "github.io".
+func IsPublicSuffix(domain string) bool {
+	name := domainKey(domain)
+	n, _ := publicSuffixLabels(name, false)
+	return n == strings.Count(name, ".")+1 && IsTLD(name[strings.LastIndexByte(name, '.')+1:])
+}
+
+// IsRegistrableDomain checks if the string is a registrable domain, a
+// public suffix plus one label, e.g. "example.co.uk" but neither "co.uk"
+// nor "www.example.co.uk".
+func IsRegistrableDomain(str string) bool {
+	name, err := ParseDomain(str, DomainOptions{})
+	if err != nil {
+		return false
+	}
+	etld1, _ := EffectiveTLDPlusOne(name)
+	return etld1 == name
+}
+
+// IsTLD checks if the string is a top-level domain of the list, e.g. "com"
+// or "uk", Unicode or Punycode.
+func IsTLD(str string) bool {
+	name := domainKey(str)
+	if name == "" || strings.IndexByte(name, '.') >= 0 {
+		return false
+	}
+	loadPublicSuffixes()
+	publicSuffixes.mu.RLock()
+	defer publicSuffixes.mu.RUnlock()
+	rule := publicSuffixes.rules[name]
+	return rule.kinds&^rule.private&(psNormal|psWildcard) != 0
+}
+
+// DomainOptions configures ParseDomain and ValidateDomain.
+type DomainOptions struct {
+	// ICANNOnly ignores the private domains of the list, so that subdomains
+	// of e.g. "github.io" are subdomains of the registrable domain
+	// "github.io" rather than registrable domains.
+	ICANNOnly bool
+}
+
+// ParseDomain checks a domain with a known top-level domain that is not a
+// public suffix, e.g. a cookie domain or the custom domain of a tenant, and
+// returns it normalized as with ParseHostname.
+func ParseDomain(str string, opts DomainOptions) (string, error) {
+	name, err := ParseHostname(str, HostnameOptions{RequireFQDN: true, AllowTrailingDot: true, AllowIDN: true})
+	if err != nil {
+		return "", errors.New("invalid domain: " + strings.TrimPrefix(err.Error(), "invalid hostname: "))
+	}
+	if tld := name[strings.LastIndexByte(name, '.')+1:]; !IsTLD(tld) {
+		return "", errors.New("invalid domain: unknown top-level domain " + tld)
+	}
+	if n, _ := publicSuffixLabels(name, opts.ICANNOnly); n == strings.Count(name, ".")+1 {
+		return "", errors.New("invalid domain: " + name + " is a public suffix")
+	}
+	return name, nil
+}
+
+// IsDomain checks if the string is a domain with a known top-level domain
+// that is not a public suffix, see ParseDomain.
+func IsDomain(str string) bool {
+	_, err := ParseDomain(str, DomainOptions{})
+	return err == nil
+}
+
+// ValidateDomain validate domain, see ParseDomain.
+func (v *Validator) ValidateDomain(data interface{}, key string, opts DomainOptions) (string, error) {
+	val, err := v.checkExist(data, key, nil)
+	if err != nil {
+		return "", err
+	}
+	name, err := ParseDomain(val.(string), opts)
+	if err != nil {
+		return "", v.error(MsgRule, key, val.(string), "rule", "domain")
+	}
+	return name, nil
+}
+
+// ValidateDomainp validate domain with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateDomainp(data interface{}, key string, opts DomainOptions, code int, message string) string {
+	name, err := v.ValidateDomain(data, key, opts)
+	if err != nil {
+		v.panicError(err, code, message)
+	}
+	return name
+}
@@ -0,0 +1,133 @@
+package vvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPublicSuffix(t *testing.T) {
+	for _, tt := range []struct {
+		domain string
+		suffix string
+		icann  bool
+		etld1  string
+	}{
+		{"www.example.co.uk", "co.uk", true, "example.co.uk"},
+		{"Example.COM.", "com", true, "example.com"},
+		{"foo.github.io", "github.io", false, "foo.github.io"},
+		{"a.b.example.ck", "example.ck", true, "b.example.ck"},
+		{"www.ck", "ck", true, "www.ck"},
+		{"a.city.kawasaki.jp", "kawasaki.jp", true, "city.kawasaki.jp"},
+		{"b.a.kawasaki.jp", "a.kawasaki.jp", true, "b.a.kawasaki.jp"},
+		{"www.食狮.公司.cn", "xn--55qx5d.cn", true, "xn--85x722f.xn--55qx5d.cn"},
+		{"example.unknowntld", "unknowntld", false, "example.unknowntld"},
+	} {
+		suffix, icann := PublicSuffix(tt.domain)
+		equal(t, tt.suffix, suffix)
+		equal(t, tt.icann, icann)
+		etld1, err := EffectiveTLDPlusOne(tt.domain)
+		equal(t, nil, err)
+		equal(t, tt.etld1, etld1)
+	}
+
+	_, err := EffectiveTLDPlusOne("co.uk")
+	equal(t, "domain co.uk is a public suffix", err.Error())
+	equal(t, true, IsPublicSuffix("co.uk"))
+	equal(t, true, IsPublicSuffix("github.io"))
+	equal(t, true, IsPublicSuffix("com"))
+	equal(t, false, IsPublicSuffix("example.com"))
+	equal(t, false, IsPublicSuffix("unknowntld"))
+}
+
+func TestIsTLD(t *testing.T) {
+	equal(t, true, IsTLD("com"))
+	equal(t, true, IsTLD("UK"))
+	equal(t, true, IsTLD("ck"))
+	equal(t, true, IsTLD("中国"))
+	equal(t, true, IsTLD("xn--fiqs8s"))
+	equal(t, false, IsTLD("invalid"))
+	equal(t, false, IsTLD("co.uk"))
+	equal(t, false, IsTLD(""))
+}
+
+func TestParseDomain(t *testing.T) {
+	for _, tt := range []struct {
+		str  string
+		opts DomainOptions
+		want string
+		err  string
+	}{
+		{"Example.co.uk", DomainOptions{}, "example.co.uk", ""},
+		{"shop.example.com.", DomainOptions{}, "shop.example.com", ""},
+		{"bücher.de", DomainOptions{}, "xn--bcher-kva.de", ""},
+		{"foo.github.io", DomainOptions{}, "foo.github.io", ""},
+		{"github.io", DomainOptions{ICANNOnly: true}, "github.io", ""},
+		{"co.uk", DomainOptions{}, "", "co.uk is a public suffix"},
+		{"github.io", DomainOptions{}, "", "github.io is a public suffix"},
+		{"example.ck", DomainOptions{}, "", "example.ck is a public suffix"},
+		{"com", DomainOptions{}, "", "host name has no top-level domain"},
+		{"example.invalid", DomainOptions{}, "", "unknown top-level domain invalid"},
+		{"exa_mple.com", DomainOptions{}, "", "invalid character '_' in domain label"},
+	} {
+		name, err := ParseDomain(tt.str, tt.opts)
+		if tt.err == "" {
+			equal(t, nil, err)
+			equal(t, tt.want, name)
+		} else if err == nil {
+			t.Errorf("%s: expected error %s", tt.str, tt.err)
+		} else {
+			equal(t, "invalid domain: "+tt.err, err.Error())
+		}
+	}
+
+	equal(t, true, IsDomain("www.example.com"))
+	equal(t, false, IsDomain("co.uk"))
+	equal(t, true, IsRegistrableDomain("example.co.uk"))
+	equal(t, false, IsRegistrableDomain("www.example.co.uk"))
+	equal(t, false, IsRegistrableDomain("co.uk"))
+	equal(t, false, IsRegistrableDomain("example.invalid"))
+}
+
+func TestKnownTLD(t *testing.T) {
+	_, err := ParseEmail("user@example.com", EmailOptions{KnownTLD: true})
+	equal(t, nil, err)
+	_, err = ParseEmail("user@example.invalid", EmailOptions{KnownTLD: true})
+	equal(t, "invalid email address: unknown top-level domain invalid", err.Error())
+	_, err = ParseURL("https://example.com/", URLOptions{KnownTLD: true})
+	equal(t, nil, err)
+	_, err = ParseURL("https://example.invalid/", URLOptions{KnownTLD: true})
+	equal(t, "invalid URL: unknown top-level domain invalid", err.Error())
+}
+
+func TestLoadPublicSuffixList(t *testing.T) {
+	defer func() {
+		equal(t, nil, LoadPublicSuffixList(strings.NewReader(publicSuffixList)))
+	}()
+	list := "// ===BEGIN ICANN DOMAINS===\ntest\n*.wild.test\n!www.wild.test\n// ===END ICANN DOMAINS===\n" +
+		"// ===BEGIN PRIVATE DOMAINS===\nhost.test\n// ===END PRIVATE DOMAINS===\n"
+	equal(t, nil, LoadPublicSuffixList(strings.NewReader(list)))
+	equal(t, true, IsTLD("test"))
+	equal(t, false, IsTLD("com"))
+	suffix, icann := PublicSuffix("a.b.wild.test")
+	equal(t, "b.wild.test", suffix)
+	equal(t, true, icann)
+	suffix, _ = PublicSuffix("a.www.wild.test")
+	equal(t, "wild.test", suffix)
+	suffix, icann = PublicSuffix("a.host.test")
+	equal(t, "host.test", suffix)
+	equal(t, false, icann)
+
+	err := LoadPublicSuffixList(strings.NewReader("exa_mple\n"))
+	equal(t, "invalid public suffix rule exa_mple: invalid character '_' in domain label", err.Error())
+}
+
+func TestValidateDomain(t *testing.T) {
+	params := map[string]string{"cookie": "example.com", "tenant": "co.uk"}
+	name, err := ValidateDomain(params, "cookie", DomainOptions{})
+	equal(t, nil, err)
+	equal(t, "example.com", name)
+	_, err = ValidateDomain(params, "tenant", DomainOptions{})
+	equal(t, "tenant must be a valid domain", err.Error())
+	_, err = ValidateDomain(params, "missing", DomainOptions{})
+	equal(t, "missing is required", err.Error())
+}
@@ -0,0 +1,184 @@
+package vvalidator
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Punycode parameters, RFC 3492 section 5.
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+	// punyMaxInt bounds the intermediate values to detect overflows.
+	punyMaxInt = 1<<31 - 1
+)
+
+var errPunycodeOverflow = errors.New("punycode overflow")
+
+// punycodeEncode encodes a Unicode string as Punycode, without the "xn--"
+// prefix of IDNA labels, e.g. "bücher" => "bcher-kva".
+func punycodeEncode(s string) (string, error) {
+	if !utf8.ValidString(s) {
+		return "", errors.New("invalid UTF-8 " + s)
+	}
+	input := []rune(s)
+	var out strings.Builder
+	for _, r := range input {
+		if r < utf8.RuneSelf {
+			out.WriteByte(byte(r))
+		}
+	}
+	b := out.Len()
+	h := b
+	if b > 0 {
+		out.WriteByte('-')
+	}
+
+	n, delta, bias := punyInitialN, 0, punyInitialBias
+	for h < len(input) {
+		m := punyMaxInt
+		for _, r := range input {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+		if (m - n) > (punyMaxInt-delta)/(h+1) {
+			return "", errPunycodeOverflow
+		}
+		delta += (m - n) * (h + 1)
+		n = m
+		for _, r := range input {
+			if int(r) < n {
+				if delta++; delta > punyMaxInt-1 {
+					return "", errPunycodeOverflow
+				}
+			}
+			if int(r) != n {
+				continue
+			}
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := punyThreshold(k, bias)
+				if q < t {
+					break
+				}
+				out.WriteByte(punyDigit(t + (q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out.WriteByte(punyDigit(q))
+			bias = punyAdapt(delta, h+1, h == b)
+			delta = 0
+			h++
+		}
+		delta++
+		n++
+	}
+	return out.String(), nil
+}
+
+// punycodeDecode decodes a Punycode string, without the "xn--" prefix.
+func punycodeDecode(s string) (string, error) {
+	var output []rune
+	pos := 0
+	if b := strings.LastIndexByte(s, '-'); b >= 0 {
+		for i := 0; i < b; i++ {
+			if s[i] >= utf8.RuneSelf {
+				return "", errors.New("invalid punycode " + s)
+			}
+			output = append(output, rune(s[i]))
+		}
+		pos = b + 1
+	}
+
+	n, i, bias := punyInitialN, 0, punyInitialBias
+	for pos < len(s) {
+		oldi, w := i, 1
+		for k := punyBase; ; k += punyBase {
+			if pos == len(s) {
+				return "", errors.New("invalid punycode " + s)
+			}
+			digit, ok := punyDigitValue(s[pos])
+			pos++
+			if !ok {
+				return "", errors.New("invalid punycode " + s)
+			}
+			if digit > (punyMaxInt-i)/w {
+				return "", errPunycodeOverflow
+			}
+			i += digit * w
+			t := punyThreshold(k, bias)
+			if digit < t {
+				break
+			}
+			if w > punyMaxInt/(punyBase-t) {
+				return "", errPunycodeOverflow
+			}
+			w *= punyBase - t
+		}
+		points := len(output) + 1
+		bias = punyAdapt(i-oldi, points, oldi == 0)
+		if i/points > punyMaxInt-n {
+			return "", errPunycodeOverflow
+		}
+		n += i / points
+		i %= points
+		if n > utf8.MaxRune || (n >= 0xD800 && n <= 0xDFFF) {
+			return "", errors.New("invalid punycode " + s)
+		}
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+	return string(output), nil
+}
+
+func punyThreshold(k, bias int) int {
+	switch {
+	case k <= bias:
+		return punyTMin
+	case k >= bias+punyTMax:
+		return punyTMax
+	}
+	return k - bias
+}
+
+func punyAdapt(delta, points int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / points
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
+
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func punyDigitValue(c byte) (int, bool) {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a'), true
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A'), true
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26, true
+	}
+	return 0, false
+}
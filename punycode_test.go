@@ -0,0 +1,35 @@
+package vvalidator
+
+import (
+	"testing"
+)
+
+func TestPunycode(t *testing.T) {
+	for _, tt := range []struct {
+		unicode, ascii string
+	}{
+		{"bücher", "bcher-kva"},
+		{"münchen", "mnchen-3ya"},
+		{"日本語", "wgv71a119e"},
+		{"правда", "80aafi6cg"},
+		{"ü", "tda"},
+		{"abc", "abc-"},
+		// RFC 3492 section 7.1 (A) and (L).
+		{"ليهمابتكلموشعربي؟", "egbpdaj6bu4bxfgehfvwxn"},
+		{"3年b組金八先生", "3b-ww4c5e180e575a65lsy2b"},
+	} {
+		ascii, err := punycodeEncode(tt.unicode)
+		equal(t, nil, err)
+		equal(t, tt.ascii, ascii)
+		unicode, err := punycodeDecode(tt.ascii)
+		equal(t, nil, err)
+		equal(t, tt.unicode, unicode)
+	}
+
+	for _, s := range []string{"bcher-kv", "bcher-kva!", "ü-tda", "99999999999a"} {
+		_, err := punycodeDecode(s)
+		equal(t, true, err != nil)
+	}
+	_, err := punycodeEncode("\xff")
+	equal(t, true, err != nil)
+}
@@ -0,0 +1,174 @@
+package vvalidator
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// RedirectOptions configures ParseRedirect and ValidateRedirect.
+type RedirectOptions struct {
+	// Origins are the origins absolute targets may redirect to, e.g.
+	// "https://app.example.com" or "https://*.example.com" for its
+	// subdomains. Without origins only relative targets are allowed.
+	Origins []string
+}
+
+// ParseRedirect parses the target of a redirect given by a user, e.g. a
+// "redirect_uri" or "next" parameter, so that it can't redirect to another
+// site. Targets are absolute paths such as "/account?tab=1", or http(s)
+// URLs on one of the origins. Scheme-relative targets ("//evil.com", also
+// percent-encoded), other schemes such as "javascript:" or "data:", and
+// control characters are rejected, so are white space and backslashes
+// before the query.
+func ParseRedirect(target string, opts RedirectOptions) (*url.URL, error) {
+	if target == "" {
+		return nil, redirectError("empty target")
+	}
+	// The query and fragment are data, the rest decides where the target
+	// leads, e.g. "/search?q=a%20b" and "/?next=%2F%2Fevil.com" are safe.
+	head, tail := target, ""
+	if i := strings.IndexAny(target, "?#"); i >= 0 {
+		head, tail = target[:i], target[i:]
+	}
+	if err := checkRedirectChars(head, true); err != nil {
+		return nil, err
+	}
+	if err := checkRedirectChars(tail, false); err != nil {
+		return nil, err
+	}
+	// Check the rest every time it's decoded, as browsers and servers may
+	// decode it before use, until it no longer changes. Decoding shortens
+	// it, so the loop ends.
+	decoded := head
+	for {
+		unescaped, err := url.PathUnescape(decoded)
+		if err != nil {
+			return nil, redirectError("invalid escape")
+		}
+		if unescaped == decoded {
+			break
+		}
+		if err := checkRedirectChars(unescaped, false); err != nil {
+			return nil, err
+		}
+		switch {
+		case strings.HasPrefix(unescaped, "//"):
+			return nil, redirectError("scheme-relative target")
+		case strings.HasPrefix(unescaped, `/\`), strings.HasPrefix(unescaped, `\`):
+			return nil, redirectError("backslash")
+		}
+		decoded = unescaped
+	}
+
+	if strings.HasPrefix(target, "/") {
+		if strings.HasPrefix(target, "//") {
+			return nil, redirectError("scheme-relative target")
+		}
+		u, err := url.Parse(target)
+		if err != nil || u.Scheme != "" || u.Host != "" {
+			return nil, redirectError("invalid path")
+		}
+		return u, nil
+	}
+
+	if !hasScheme(target) {
+		return nil, redirectError("not an absolute path or URL")
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, redirectError("invalid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, redirectError("scheme " + u.Scheme + " is not allowed")
+	}
+	if u.User != nil {
+		return nil, redirectError("userinfo is not allowed")
+	}
+	for _, origin := range opts.Origins {
+		if matchOrigin(origin, u) {
+			return u, nil
+		}
+	}
+	return nil, redirectError("origin " + u.Scheme + "://" + u.Host + " is not allowed")
+}
+
+// IsSafeRedirect checks if the string is a safe redirect target to one of
+// the origins, see ParseRedirect.
+func IsSafeRedirect(str string, origins ...string) bool {
+	_, err := ParseRedirect(str, RedirectOptions{Origins: origins})
+	return err == nil
+}
+
+func redirectError(reason string) error {
+	return errors.New("unsafe redirect: " + reason)
+}
+
+// checkRedirectChars rejects the control characters browsers ignore in
+// URLs, strict also rejects white space and backslashes, read as slashes.
+func checkRedirectChars(s string, strict bool) error {
+	for _, r := range s {
+		switch {
+		case r < 0x20 || r == 0x7f || (r >= 0x80 && r <= 0x9f):
+			if strict {
+				return redirectError("control character or white space")
+			}
+			return redirectError("control character")
+		case strict && r == '\\':
+			return redirectError("backslash")
+		case strict && r == ' ':
+			return redirectError("control character or white space")
+		}
+	}
+	return nil
+}
+
+// matchOrigin reports whether the URL is on an origin such as
+// "https://app.example.com" or "https://*.example.com:8443".
+func matchOrigin(origin string, u *url.URL) bool {
+	o, err := url.Parse(origin)
+	if err != nil || !strings.EqualFold(o.Scheme, u.Scheme) {
+		return false
+	}
+	if originPort(o) != originPort(u) {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if ascii, err := asciiDomain(host, true); err == nil {
+		host = ascii
+	}
+	return matchHosts([]string{o.Hostname()}, host)
+}
+
+// originPort returns the port of an http(s) URL, the default one if none.
+func originPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	if strings.EqualFold(u.Scheme, "https") {
+		return "443"
+	}
+	return "80"
+}
+
+// ValidateRedirect validate redirect target, see ParseRedirect.
+func (v *Validator) ValidateRedirect(data interface{}, key string, opts RedirectOptions) (string, error) {
+	val, err := v.checkExist(data, key, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := ParseRedirect(val.(string), opts); err != nil {
+		return "", v.error(MsgRule, key, val.(string), "rule", "redirect")
+	}
+	return val.(string), nil
+}
+
+// ValidateRedirectp validate redirect target with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateRedirectp(data interface{}, key string, opts RedirectOptions, code int, message string) string {
+	val, err := v.ValidateRedirect(data, key, opts)
+	if err != nil {
+		v.panicError(err, code, message)
+	}
+	return val
+}
@@ -0,0 +1,89 @@
+package vvalidator
+
+import (
+	"testing"
+)
+
+func TestParseRedirect(t *testing.T) {
+	u, err := ParseRedirect("/account?tab=1#top", RedirectOptions{})
+	equal(t, nil, err)
+	equal(t, "/account", u.Path)
+	equal(t, "tab=1", u.RawQuery)
+
+	opts := RedirectOptions{Origins: []string{"https://app.example.com", "https://*.example.org:8443", "http://localhost:3000"}}
+	for _, tt := range []struct {
+		target string
+		err    string
+	}{
+		{"/", ""},
+		{"/a/b?next=//evil.com", ""},
+		{"/caf%C3%A9", ""},
+		{"/search?q=a%20b", ""},
+		{"/search?q=a%5Cb&next=%2F%2Fevil.com#a%20b", ""},
+		{"/a%5Cb", ""},
+		{"/my%20file", ""},
+		{"https://app.example.com/cb?code=1", ""},
+		{"https://APP.example.com:443/", ""},
+		{"https://a.b.example.org:8443/", ""},
+		{"http://localhost:3000/", ""},
+		{"", "empty target"},
+		{"//evil.com", "scheme-relative target"},
+		{"///evil.com", "scheme-relative target"},
+		{"/%2F/evil.com", "scheme-relative target"},
+		{"%2F%2Fevil.com", "scheme-relative target"},
+		{"/%252F/evil.com", "scheme-relative target"},
+		{"/%25255cevil.com", "backslash"},
+		{"/%2525252F/evil.com", "scheme-relative target"},
+		{`/\evil.com`, "backslash"},
+		{`\\evil.com`, "backslash"},
+		{"/%5Cevil.com", "backslash"},
+		{"/\t/evil.com", "control character or white space"},
+		{"/%09/evil.com", "control character"},
+		{"/%0d%0aSet-Cookie:a=b", "control character"},
+		{"/search?q=a\tb", "control character"},
+		{"/search#\n", "control character"},
+		{" https://app.example.com/", "control character or white space"},
+		{"/%zz", "invalid escape"},
+		{"account", "not an absolute path or URL"},
+		{"javascript:alert(1)", "not an absolute path or URL"},
+		{"data:text/html,<script>alert(1)</script>", "not an absolute path or URL"},
+		{"https:evil.com", "not an absolute path or URL"},
+		{"https:/evil.com", "not an absolute path or URL"},
+		{"javascript://app.example.com/%0aalert(1)", "control character"},
+		{"javascript://app.example.com/", "scheme javascript is not allowed"},
+		{"ftp://app.example.com/", "scheme ftp is not allowed"},
+		{"https://app.example.com@evil.com/", "userinfo is not allowed"},
+		{"https://evil.com/", "origin https://evil.com is not allowed"},
+		{"http://app.example.com/", "origin http://app.example.com is not allowed"},
+		{"https://app.example.com:8443/", "origin https://app.example.com:8443 is not allowed"},
+		{"https://example.org:8443/", "origin https://example.org:8443 is not allowed"},
+		{"https://evilexample.org:8443/", "origin https://evilexample.org:8443 is not allowed"},
+		{"https://app.example.com.evil.com/", "origin https://app.example.com.evil.com is not allowed"},
+	} {
+		_, err := ParseRedirect(tt.target, opts)
+		if tt.err == "" {
+			equal(t, nil, err)
+		} else if err == nil {
+			t.Errorf("%s: expected error %s", tt.target, tt.err)
+		} else {
+			equal(t, "unsafe redirect: "+tt.err, err.Error())
+		}
+	}
+
+	equal(t, true, IsSafeRedirect("/home"))
+	equal(t, false, IsSafeRedirect("https://app.example.com/"))
+	equal(t, true, IsSafeRedirect("https://app.example.com/", "https://app.example.com"))
+	equal(t, false, IsSafeRedirect("/%25255cevil.com"))
+}
+
+func TestValidateRedirect(t *testing.T) {
+	params := map[string]string{"next": "/dashboard", "redirect_uri": "//evil.com"}
+	opts := RedirectOptions{Origins: []string{"https://app.example.com"}}
+	next, err := ValidateRedirect(params, "next", opts)
+	equal(t, nil, err)
+	equal(t, "/dashboard", next)
+	_, err = ValidateRedirect(params, "redirect_uri", opts)
+	equal(t, "redirect_uri must be a valid redirect", err.Error())
+	_, err = ValidateRedirect(params, "missing", opts)
+	equal(t, "missing is required", err.Error())
+}
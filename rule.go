@@ -0,0 +1,588 @@
+package vvalidator
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// RuleFunc checks a value against a named rule, param is the text after the
+// colon of the rule, e.g. "md5" in "hash:md5".
+type RuleFunc func(value, param string) bool
+
+// Predicate is a builtin check of this package, it's a named rule and a
+// function of expressions.
+type Predicate struct {
+	// Func is the name of the function, e.g. "IsEmail".
+	Func string
+	// Rule is the name of the rule, e.g. "email", empty if the predicate is
+	// only a function of expressions.
+	Rule string
+	// Param reports whether the function takes the rule parameter as its
+	// second argument, e.g. IsHash(str, algorithm) for "hash:md5".
+	Param bool
+	// Check calls the function.
+	Check RuleFunc
+}
+
+var (
+	// predicates are the builtin predicates, the rules of every validator
+	// and the functions of expressions derive from them.
+	predicates = []Predicate{
+		predicate("IsNumeric", "numeric", IsNumeric),
+		predicate("IsInt", "", IsInt),
+		predicate("IsFloat", "", IsFloat),
+		predicate("IsHexadecimal", "hexadecimal", IsHexadecimal),
+		predicate("IsAlpha", "alpha", IsAlpha),
+		predicate("IsAlphanumeric", "alphanumeric", IsAlphanumeric),
+		predicate("IsIP", "ip", IsIP),
+		predicate("IsIPv4", "ipv4", IsIPv4),
+		predicate("IsIPv6", "ipv6", IsIPv6),
+		predicate("IsCIDR", "cidr", IsCIDR),
+		predicate("IsIPv4CIDR", "ipv4cidr", IsIPv4CIDR),
+		predicate("IsIPv6CIDR", "ipv6cidr", IsIPv6CIDR),
+		predicate("IsIPRange", "iprange", IsIPRange),
+		predicate("IsLatitude", "latitude", IsLatitude),
+		predicate("IsLongitude", "longitude", IsLongitude),
+		predicate("IsBase64", "base64", IsBase64),
+		predicate("IsPort", "port", IsPort),
+		predicate("IsPortRange", "portrange", IsPortRange),
+		predicate("IsHostPort", "hostport", IsHostPort),
+		predicate("IsHostname", "hostname", IsHostname),
+		predicate("IsFQDN", "fqdn", IsFQDN),
+		predicate("IsDNSLabel", "", IsDNSLabel),
+		predicate("IsDomain", "domain", IsDomain),
+		predicate("IsTLD", "tld", IsTLD),
+		predicate("IsPublicSuffix", "", IsPublicSuffix),
+		predicate("IsRegistrableDomain", "", IsRegistrableDomain),
+		predicate("IsURL", "url", IsURL),
+		predicate("IsASCII", "ascii", IsASCII),
+		predicate("IsPrintableASCII", "printableascii", IsPrintableASCII),
+		predicate("IsEmail", "email", IsEmail),
+		predicate("IsWinPath", "winpath", IsWinPath),
+		predicate("IsUnixPath", "unixpath", IsUnixPath),
+		predicate("IsSemver", "semver", IsSemver),
+		predicate("IsFullWidth", "fullwidth", IsFullWidth),
+		predicate("IsHalfWidth", "halfwidth", IsHalfWidth),
+		{Func: "IsHash", Rule: "hash", Param: true, Check: IsHash},
+		predicate("IsMAC", "mac", IsMAC),
+		{Func: "IsTime", Rule: "time", Param: true, Check: IsTime},
+		predicate("IsRFC3339Time", "rfc3339", IsRFC3339Time),
+		predicate("IsRFC3339WithoutZoneTime", "rfc3339nozone", IsRFC3339WithoutZoneTime),
+		predicate("IsJSON", "json", IsJSON),
+		predicate("IsUTFLetter", "utfletter", IsUTFLetter),
+		predicate("IsUTFLetterNumeric", "utfletternumeric", IsUTFLetterNumeric),
+		predicate("IsHexColor", "hexcolor", IsHexColor),
+		predicate("IsRGBColor", "rgbcolor", IsRGBColor),
+		predicate("IsRGBAColor", "rgbacolor", IsRGBAColor),
+		predicate("IsUUID", "uuid", IsUUID),
+		predicate("IsE164", "e164", IsE164),
+		{Func: "IsPhone", Rule: "phone", Param: true, Check: IsPhone},
+		predicate("IsLowerCase", "lowercase", IsLowerCase),
+		predicate("IsUpperCase", "uppercase", IsUpperCase),
+		predicate("HasLowerCase", "haslowercase", HasLowerCase),
+		predicate("HasUpperCase", "hasuppercase", HasUpperCase),
+	}
+	// builtinRules are the named rules every validator starts with.
+	builtinRules = predicateRules()
+	// ruleCache holds the parsed rule strings, they don't depend on the validator.
+	ruleCache = newCache(maxCacheSize)
+)
+
+func predicateRules() map[string]RuleFunc {
+	rules := make(map[string]RuleFunc, len(predicates))
+	for _, p := range predicates {
+		if p.Rule != "" {
+			rules[p.Rule] = p.Check
+		}
+	}
+	return rules
+}
+
+// Predicates returns the builtin predicates, e.g. for code generators.
+func Predicates() []Predicate {
+	return append([]Predicate(nil), predicates...)
+}
+
+func predicate(name, rule string, fn func(string) bool) Predicate {
+	return Predicate{Func: name, Rule: rule, Check: predicateRule(fn)}
+}
+
+func predicateRule(fn func(string) bool) RuleFunc {
+	return func(value, param string) bool {
+		return fn(value)
+	}
+}
+
+// Rule is a parsed rule string such as "required|int|min:0|max:200".
+type Rule struct {
+	// Required rejects missing and empty values.
+	Required bool
+	// Kind is the value type: int, int64, float or string, empty means string.
+	Kind string
+	// Min and Max are the bounds, of the value or its length, empty means none.
+	Min, Max string
+	// In are the allowed values, nil means any.
+	In []string
+	// Pattern is a regular expression the value must match.
+	Pattern string
+	// Expr is an expression over the fields that must hold.
+	Expr *Expr
+	// Checks are the named rules, in order.
+	Checks []RuleCheck
+}
+
+// RuleCheck is a named rule and its parameter, e.g. "hash" and "md5".
+type RuleCheck struct {
+	Name, Param string
+}
+
+// ParseRule parses a rule string, the rules are separated by "|".
+// "pattern" and "expr" take the rest of the string as their parameter,
+// so they can contain "|" and must be the last rule. Named rules are not
+// looked up, they may be registered later.
+func ParseRule(rule string) (*Rule, error) {
+	rs := &Rule{}
+	rest := rule
+	for rest != "" {
+		item := rest
+		rest = ""
+		if trimmed := strings.TrimSpace(item); !strings.HasPrefix(trimmed, "pattern:") && !strings.HasPrefix(trimmed, "expr:") {
+			if i := strings.IndexByte(item, '|'); i >= 0 {
+				item, rest = item[:i], item[i+1:]
+			}
+		}
+		name, param := item, ""
+		if i := strings.IndexByte(item, ':'); i >= 0 {
+			name, param = item[:i], item[i+1:]
+		}
+		name = strings.TrimSpace(name)
+
+		switch name {
+		case "":
+		case "required":
+			rs.Required = true
+		case "int", "int64", "float", "string":
+			rs.Kind = name
+		case "min":
+			rs.Min = param
+		case "max":
+			rs.Max = param
+		case "in":
+			rs.In = strings.Split(param, ",")
+		case "pattern":
+			rs.Pattern = param
+		case "expr":
+			e, err := CompileExpr(param)
+			if err != nil {
+				return nil, err
+			}
+			rs.Expr = e
+		default:
+			rs.Checks = append(rs.Checks, RuleCheck{Name: name, Param: param})
+		}
+	}
+
+	for _, bound := range []string{rs.Min, rs.Max} {
+		if bound == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(bound, 64); err != nil {
+			return nil, errors.New("invalid bound " + bound + " in rule " + rule)
+		}
+	}
+	return rs, nil
+}
+
+// parseRule is ParseRule with a cache, the returned rule must not be modified.
+func parseRule(rule string) (*Rule, error) {
+	if rs, ok := ruleCache.load(rule); ok {
+		return rs.(*Rule), nil
+	}
+	rs, err := ParseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	ruleCache.store(rule, rs)
+	return rs, nil
+}
+
+// validateRule checks fields[key] against the rule set.
+// Optional fields that are missing or empty are not checked.
+func (v *Validator) validateRule(rs *Rule, fields map[string]string, key string) error {
+	if fields[key] == "" {
+		if rs.Required {
+			_, err := v.checkExist(fields, key, nil)
+			return err
+		}
+		return nil
+	}
+
+	// The bounds are compared here, the Validate* functions take -1 for no bound.
+	var err error
+	var below, above bool
+	minID, maxID := MsgMin, MsgMax
+	switch rs.Kind {
+	case "int", "int64":
+		var n int64
+		if rs.Kind == "int" {
+			var i int
+			i, err = v.ValidateInt(fields, key, -1, -1)
+			n = int64(i)
+		} else {
+			n, err = v.ValidateInt64(fields, key, -1, -1)
+		}
+		min, hasMin := ruleInt(rs.Min, true)
+		max, hasMax := ruleInt(rs.Max, false)
+		below, above = hasMin && n < min, hasMax && n > max
+	case "float":
+		var f float64
+		f, err = v.ValidateFloat(fields, key, -1, -1)
+		min, hasMin := ruleFloat(rs.Min)
+		max, hasMax := ruleFloat(rs.Max)
+		below, above = hasMin && f < min, hasMax && f > max
+	default:
+		n := int64(utf8.RuneCountInString(fields[key]))
+		min, hasMin := ruleInt(rs.Min, true)
+		max, hasMax := ruleInt(rs.Max, false)
+		below, above = hasMin && n < min, hasMax && n > max
+		minID, maxID = MsgMinLength, MsgMaxLength
+	}
+	if err != nil {
+		return err
+	}
+	if below {
+		return v.error(minID, key, fields[key], rs.bounds()...)
+	}
+	if above {
+		return v.error(maxID, key, fields[key], rs.bounds()...)
+	}
+	if rs.In != nil && !rs.contains(fields[key]) {
+		return v.error(MsgInvalid, key, fields[key])
+	}
+
+	if rs.Pattern != "" {
+		if _, err := v.ValidateStringWithPattern(fields, key, rs.Pattern); err != nil {
+			return err
+		}
+	}
+	for _, c := range rs.Checks {
+		fn, ok := v.lookupRule(c.Name)
+		if !ok {
+			return errors.New("unknown rule " + c.Name)
+		}
+		if !fn(fields[key], c.Param) {
+			return v.error(MsgRule, key, fields[key], "rule", c.Name)
+		}
+	}
+	if rs.Expr != nil {
+		// Evaluation errors come from the data, e.g. a non-numeric operand.
+		if ok, err := rs.Expr.Match(fields); err != nil || !ok {
+			return v.error(MsgInvalid, key, fields[key])
+		}
+	}
+	return nil
+}
+
+// ruleInt returns an integer bound, ok is false if the rule has none.
+// Integers are parsed exactly, other numbers are rounded up for a min
+// bound and down for a max bound.
+func ruleInt(bound string, min bool) (n int64, ok bool) {
+	if bound == "" {
+		return 0, false
+	}
+	if n, err := strconv.ParseInt(bound, 10, 64); err == nil {
+		return n, true
+	}
+	f, _ := strconv.ParseFloat(bound, 64)
+	if min {
+		f = math.Ceil(f)
+	} else {
+		f = math.Floor(f)
+	}
+	switch {
+	case f >= math.MaxInt64:
+		return math.MaxInt64, true
+	case f <= math.MinInt64:
+		return math.MinInt64, true
+	}
+	return int64(f), true
+}
+
+// ruleFloat returns a float bound, ok is false if the rule has none.
+func ruleFloat(bound string) (f float64, ok bool) {
+	if bound == "" {
+		return 0, false
+	}
+	f, _ = strconv.ParseFloat(bound, 64)
+	return f, true
+}
+
+// bounds returns the min and max message parameters of the rule.
+func (rs *Rule) bounds() []string {
+	var params []string
+	for _, b := range [][2]string{{"min", rs.Min}, {"max", rs.Max}} {
+		if rs.Kind == "float" {
+			if f, ok := ruleFloat(b[1]); ok {
+				params = append(params, b[0], strconv.FormatFloat(f, 'f', -1, 64))
+			}
+		} else if n, ok := ruleInt(b[1], b[0] == "min"); ok {
+			params = append(params, b[0], strconv.FormatInt(n, 10))
+		}
+	}
+	return params
+}
+
+// contains reports whether value equals one of the "in" values,
+// numbers are compared by value.
+func (rs *Rule) contains(value string) bool {
+	for _, v := range rs.In {
+		v = strings.TrimSpace(v)
+		switch rs.Kind {
+		case "int", "int64":
+			x, err1 := strconv.ParseInt(value, 10, 64)
+			y, err2 := strconv.ParseInt(v, 10, 64)
+			if err1 == nil && err2 == nil && x == y {
+				return true
+			}
+		case "float":
+			x, err1 := strconv.ParseFloat(value, 64)
+			y, err2 := strconv.ParseFloat(v, 64)
+			if err1 == nil && err2 == nil && x == y {
+				return true
+			}
+		default:
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateRule validate a field with a rule string like "required|int|min:0|max:200".
+// data is a string or map[string]string as with the other validators.
+func (v *Validator) ValidateRule(data interface{}, key, rule string) error {
+	var fields map[string]string
+	switch data.(type) {
+	case string:
+		fields = map[string]string{key: data.(string)}
+	case map[string]string:
+		fields = data.(map[string]string)
+	default:
+		return v.error(MsgDataType, key, "", "types", "string or map[string]string")
+	}
+	rs, err := parseRule(rule)
+	if err != nil {
+		return err
+	}
+	return v.validateRule(rs, v.trim(fields), key)
+}
+
+// ValidateRulep validate a field with a rule string with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateRulep(data interface{}, key, rule string, code int, message string) {
+	if err := v.ValidateRule(data, key, rule); err != nil {
+		v.panicError(err, code, message)
+	}
+}
+
+// ValidateRules validate the fields of data with rule strings keyed by field name.
+// The validation errors of all fields are returned as Errors, in key order.
+func (v *Validator) ValidateRules(data map[string]string, rules map[string]string) error {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fields := v.trim(data)
+	var errs Errors
+	for _, key := range keys {
+		rs, err := parseRule(rules[key])
+		if err != nil {
+			return err
+		}
+		if errs, err = errs.add(v.validateRule(rs, fields, key)); err != nil {
+			return err
+		}
+	}
+	if v.Strict {
+		keys = keys[:0]
+		for key := range data {
+			if _, ok := rules[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			errs, _ = errs.add(v.error(MsgNotAllowed, key, data[key]))
+		}
+	}
+	return errs.err()
+}
+
+// ValidateRulesp validate the fields of data with rule strings with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateRulesp(data map[string]string, rules map[string]string, code int, message string) {
+	if err := v.ValidateRules(data, rules); err != nil {
+		v.panicError(err, code, message)
+	}
+}
+
+// ValidateStruct validate a struct with the rule strings in its `valid` tags:
+//
+//	type Order struct {
+//	    Price    float64 `json:"price" valid:"required|min:0"`
+//	    Discount float64 `json:"discount" valid:"expr:discount <= price * 0.5"`
+//	}
+//
+// Fields are named by their json tag, or the field name without one. The value
+// type (int, int64, float, string) defaults to the Go type of the field, nil
+// pointers count as missing. The validation errors of all fields are
+// returned as Errors, in field order.
+func (v *Validator) ValidateStruct(s interface{}) error {
+	fields, rules, err := structRules(s)
+	if err != nil {
+		return err
+	}
+	fields = v.trim(fields)
+	var errs Errors
+	for _, r := range rules {
+		if errs, err = errs.add(v.validateRule(r.set, fields, r.key)); err != nil {
+			return err
+		}
+	}
+	return errs.err()
+}
+
+// ValidateStructp validate a struct with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateStructp(s interface{}, code int, message string) {
+	if err := v.ValidateStruct(s); err != nil {
+		v.panicError(err, code, message)
+	}
+}
+
+// structKinds maps Go kinds to the value type of their rules.
+var structKinds = map[reflect.Kind]string{
+	reflect.Int:     "int",
+	reflect.Int8:    "int",
+	reflect.Int16:   "int",
+	reflect.Int32:   "int",
+	reflect.Uint8:   "int",
+	reflect.Uint16:  "int",
+	reflect.Int64:   "int64",
+	reflect.Uint:    "int64",
+	reflect.Uint32:  "int64",
+	reflect.Uint64:  "int64",
+	reflect.Float32: "float",
+	reflect.Float64: "float",
+	reflect.String:  "string",
+	reflect.Bool:    "string",
+}
+
+type structRule struct {
+	key string
+	set *Rule
+}
+
+// structField is a tagged field of a struct and its parsed rules.
+type structField struct {
+	structRule
+	index int
+	// kind is the kind of the field type, pointers dereferenced.
+	kind reflect.Kind
+}
+
+// structFields returns the exported fields of a struct type with a valid
+// tag, in field order. Rules without a type get the type of the field.
+// ValidateStruct and StructSchema both walk structs with it.
+func structFields(rt reflect.Type) ([]structField, error) {
+	var fields []structField
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag, ok := sf.Tag.Lookup("valid")
+		if !ok || sf.PkgPath != "" {
+			continue
+		}
+		key := structKey(sf)
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		kind, ok := structKinds[ft.Kind()]
+		if !ok {
+			return nil, errors.New(key + " has unsupported type " + sf.Type.String())
+		}
+		rs, err := parseRule(tag)
+		if err != nil {
+			return nil, err
+		}
+		if rs.Kind == "" {
+			typed := *rs
+			typed.Kind = kind
+			rs = &typed
+		}
+		fields = append(fields, structField{structRule: structRule{key: key, set: rs}, index: i, kind: ft.Kind()})
+	}
+	return fields, nil
+}
+
+// structRules flattens the tagged fields of a struct into string values
+// and their parsed rules, in field order.
+func structRules(s interface{}) (map[string]string, []structRule, error) {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, nil, errors.New("data type invalid, must be struct or pointer to struct")
+	}
+
+	sfs, err := structFields(rv.Type())
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := make(map[string]string)
+	rules := make([]structRule, 0, len(sfs))
+	for _, f := range sfs {
+		fv := rv.Field(f.index)
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.Ptr {
+			fields[f.key] = structValue(fv)
+		}
+		rules = append(rules, f.structRule)
+	}
+	return fields, rules, nil
+}
+
+// structKey returns the field key of a struct field, its json name or the field name.
+func structKey(sf reflect.StructField) string {
+	if name := strings.Split(sf.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return sf.Name
+}
+
+func structValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	}
+	return v.String()
+}
@@ -0,0 +1,79 @@
+package vvalidator
+
+import (
+	"testing"
+)
+
+func TestRule(t *testing.T) {
+	params := map[string]string{
+		"uid":      "123",
+		"email":    "a@b.com",
+		"currency": "USD",
+		"price":    "100",
+		"discount": "80",
+	}
+
+	equal(t, nil, ValidateRule(params, "uid", "required|int|min:0|max:200"))
+	equal(t, "uid is too big (maximum is 10)", ValidateRule(params, "uid", "int|max:10").Error())
+	equal(t, "nickname is required", ValidateRule(params, "nickname", "required").Error())
+	equal(t, nil, ValidateRule(params, "nickname", "max:10"))
+	equal(t, nil, ValidateRule(params, "currency", "in:USD,EUR|pattern:^(USD|EUR)$"))
+	equal(t, "currency is invalid", ValidateRule(params, "currency", "in:CNY").Error())
+	equal(t, "email must be a valid ipv4", ValidateRule(params, "email", "email|ipv4").Error())
+	equal(t, "unknown rule foo", ValidateRule(params, "email", "foo").Error())
+	equal(t, "discount is invalid", ValidateRule(params, "discount", "float|expr:discount <= price * 0.5").Error())
+	equal(t, "discount is invalid", ValidateRule(map[string]string{"discount": "20", "price": "abc"}, "discount", "expr:discount <= price * 0.5").Error())
+
+	// bounds are compared exactly, -1 is a bound like any other
+	big := map[string]string{"id": "9007199254740993", "n": "-2", "m": "-1", "k": "1"}
+	equal(t, nil, ValidateRule(big, "id", "int64|max:9007199254740993"))
+	equal(t, "id is too big (maximum is 9007199254740992)", ValidateRule(big, "id", "int64|max:9007199254740992").Error())
+	equal(t, "n is too small (minimum is -1)", ValidateRule(big, "n", "int|min:-1").Error())
+	equal(t, nil, ValidateRule(big, "m", "int|min:-1"))
+	equal(t, "k is too small (minimum is 2)", ValidateRule(big, "k", "int|min:1.5").Error())
+	equal(t, "k is too short (minimum is 2 characters)", ValidateRule(big, "k", "min:1.5").Error())
+
+	RegisterRule("currency", func(value, param string) bool { return len(value) == 3 })
+	equal(t, nil, ValidateRules(params, map[string]string{"currency": "currency", "uid": "int"}))
+
+	type order struct {
+		Price    float64 `json:"price" valid:"required|min:0"`
+		Discount float64 `json:"discount" valid:"expr:discount <= price * 0.5"`
+		Currency *string `json:"currency" valid:"required|in:USD,EUR"`
+		Note     string
+	}
+	usd := "USD"
+	equal(t, nil, ValidateStruct(&order{Price: 100, Discount: 20, Currency: &usd}))
+	equal(t, "discount is invalid", ValidateStruct(order{Price: 100, Discount: 60, Currency: &usd}).Error())
+	equal(t, "currency is required", ValidateStruct(order{Price: 100}).Error())
+}
+
+func TestParseRule(t *testing.T) {
+	r, err := ParseRule("required|int|min:0|max:200|in:1,2|hash:md5|expr:uid > 0 || uid == 0")
+	equal(t, nil, err)
+	equal(t, true, r.Required)
+	equal(t, "int", r.Kind)
+	equal(t, "0", r.Min)
+	equal(t, "200", r.Max)
+	equal(t, []string{"1", "2"}, r.In)
+	equal(t, []RuleCheck{{Name: "hash", Param: "md5"}}, r.Checks)
+	equal(t, "uid > 0 || uid == 0", r.Expr.String())
+	_, err = ParseRule("min:x")
+	equal(t, "invalid bound x in rule min:x", err.Error())
+
+	// Every rule and expression function derives from the predicates.
+	for _, p := range Predicates() {
+		_, err := CompileExpr(p.Func + `("a", "b")`)
+		if p.Param {
+			equal(t, nil, err)
+		} else {
+			equal(t, true, err != nil)
+			_, err = CompileExpr(p.Func + `("a")`)
+			equal(t, nil, err)
+		}
+		if p.Rule != "" {
+			_, ok := std.lookupRule(p.Rule)
+			equal(t, true, ok)
+		}
+	}
+}
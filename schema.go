@@ -0,0 +1,894 @@
+package vvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSchemaDepth limits the nesting of schemas applied to a value, so that
+// $ref cycles and hostile input cannot exhaust the stack.
+const maxSchemaDepth = 1000
+
+// SchemaFormats maps the JSON Schema format values onto checks, formats
+// that are not listed are not checked.
+var SchemaFormats = map[string]func(string) bool{
+	"email":     IsEmail,
+	"ipv4":      IsIPv4,
+	"ipv6":      IsIPv6,
+	"hostname":  IsHostname,
+	"uri":       IsURL,
+	"date-time": IsRFC3339Time,
+	"uuid":      IsUUID,
+}
+
+// Schema is a compiled JSON Schema. A Schema is immutable and may be used
+// concurrently.
+//
+// The supported subset of draft 2020-12 is: type, required, properties,
+// additionalProperties (false only), items, enum, const, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, minLength, maxLength, minItems,
+// maxItems, pattern, format (see SchemaFormats), oneOf, anyOf, allOf and
+// $ref within the document, e.g. "#/$defs/address". Other keywords are
+// ignored. Patterns use the RE2 syntax of package regexp.
+type Schema struct {
+	source []byte
+	root   *schemaNode
+}
+
+// schemaNode is a compiled schema object, bool schemas are nodes that
+// accept everything or nothing.
+type schemaNode struct {
+	reject     bool
+	ref        *schemaNode
+	types      []string
+	required   []string
+	properties map[string]*schemaNode
+	noExtra    bool
+	items      *schemaNode
+	enum       []interface{}
+	constant   []interface{} // a single value if set
+	minimum    *float64
+	maximum    *float64
+	exclMin    *float64
+	exclMax    *float64
+	minLength  *int
+	maxLength  *int
+	minItems   *int
+	maxItems   *int
+	pattern    *regexp.Regexp
+	format     string
+	allOf      []*schemaNode
+	anyOf      []*schemaNode
+	oneOf      []*schemaNode
+}
+
+// CompileSchema compiles a JSON Schema document so that it can validate many values.
+func CompileSchema(schema []byte) (*Schema, error) {
+	var doc interface{}
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, errors.New("invalid schema: " + err.Error())
+	}
+	c := &schemaCompiler{doc: doc, nodes: make(map[string]*schemaNode)}
+	root, err := c.compile(doc, "#")
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{source: schema, root: root}, nil
+}
+
+// MustCompileSchema is like CompileSchema but panics if the schema cannot be compiled.
+func MustCompileSchema(schema []byte) *Schema {
+	s, err := CompileSchema(schema)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// String returns the source of the schema.
+func (s *Schema) String() string {
+	return string(s.source)
+}
+
+// schemaCompiler compiles the nodes of a document by JSON pointer, so that
+// each $ref target is compiled once and recursive schemas terminate.
+type schemaCompiler struct {
+	doc   interface{}
+	nodes map[string]*schemaNode
+}
+
+func (c *schemaCompiler) compile(raw interface{}, ptr string) (*schemaNode, error) {
+	if n, ok := c.nodes[ptr]; ok {
+		return n, nil
+	}
+	n := &schemaNode{}
+	c.nodes[ptr] = n
+
+	switch raw.(type) {
+	case bool:
+		n.reject = !raw.(bool)
+		return n, nil
+	case map[string]interface{}:
+	default:
+		return nil, schemaError(ptr, "schema must be an object or a bool")
+	}
+	obj := raw.(map[string]interface{})
+
+	var err error
+	if ref, ok := obj["$ref"]; ok {
+		s, ok := ref.(string)
+		if !ok {
+			return nil, schemaError(ptr, "$ref must be a string")
+		}
+		if n.ref, err = c.resolve(s); err != nil {
+			return nil, err
+		}
+	}
+
+	switch t := obj["type"].(type) {
+	case nil:
+	case string:
+		n.types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, schemaError(ptr, "type must be a string or an array of strings")
+			}
+			n.types = append(n.types, s)
+		}
+	default:
+		return nil, schemaError(ptr, "type must be a string or an array of strings")
+	}
+	for _, t := range n.types {
+		switch t {
+		case "null", "boolean", "object", "array", "number", "integer", "string":
+		default:
+			return nil, schemaError(ptr, "unknown type "+t)
+		}
+	}
+
+	if req, ok := obj["required"]; ok {
+		items, ok := req.([]interface{})
+		if !ok {
+			return nil, schemaError(ptr, "required must be an array of strings")
+		}
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return nil, schemaError(ptr, "required must be an array of strings")
+			}
+			n.required = append(n.required, s)
+		}
+	}
+
+	if props, ok := obj["properties"]; ok {
+		m, ok := props.(map[string]interface{})
+		if !ok {
+			return nil, schemaError(ptr, "properties must be an object")
+		}
+		n.properties = make(map[string]*schemaNode, len(m))
+		for name, sub := range m {
+			if n.properties[name], err = c.compile(sub, ptr+"/properties/"+escapePointer(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if extra, ok := obj["additionalProperties"]; ok {
+		b, ok := extra.(bool)
+		if !ok {
+			return nil, schemaError(ptr, "additionalProperties must be a bool")
+		}
+		n.noExtra = !b
+	}
+	if items, ok := obj["items"]; ok {
+		if n.items, err = c.compile(items, ptr+"/items"); err != nil {
+			return nil, err
+		}
+	}
+
+	if enum, ok := obj["enum"]; ok {
+		if n.enum, ok = enum.([]interface{}); !ok {
+			return nil, schemaError(ptr, "enum must be an array")
+		}
+	}
+	if constant, ok := obj["const"]; ok {
+		n.constant = []interface{}{constant}
+	}
+
+	for kw, dst := range map[string]**float64{
+		"minimum":          &n.minimum,
+		"maximum":          &n.maximum,
+		"exclusiveMinimum": &n.exclMin,
+		"exclusiveMaximum": &n.exclMax,
+	} {
+		if val, ok := obj[kw]; ok {
+			f, ok := val.(float64)
+			if !ok {
+				return nil, schemaError(ptr, kw+" must be a number")
+			}
+			*dst = &f
+		}
+	}
+	for kw, dst := range map[string]**int{
+		"minLength": &n.minLength,
+		"maxLength": &n.maxLength,
+		"minItems":  &n.minItems,
+		"maxItems":  &n.maxItems,
+	} {
+		if val, ok := obj[kw]; ok {
+			f, ok := val.(float64)
+			if !ok || f < 0 || f != math.Trunc(f) {
+				return nil, schemaError(ptr, kw+" must be a non-negative integer")
+			}
+			i := int(f)
+			*dst = &i
+		}
+	}
+
+	if pattern, ok := obj["pattern"]; ok {
+		s, ok := pattern.(string)
+		if !ok {
+			return nil, schemaError(ptr, "pattern must be a string")
+		}
+		if n.pattern, err = regexp.Compile(s); err != nil {
+			return nil, schemaError(ptr, "invalid pattern: "+err.Error())
+		}
+	}
+	if format, ok := obj["format"]; ok {
+		if n.format, ok = format.(string); !ok {
+			return nil, schemaError(ptr, "format must be a string")
+		}
+	}
+
+	for kw, dst := range map[string]*[]*schemaNode{
+		"allOf": &n.allOf,
+		"anyOf": &n.anyOf,
+		"oneOf": &n.oneOf,
+	} {
+		val, ok := obj[kw]
+		if !ok {
+			continue
+		}
+		subs, ok := val.([]interface{})
+		if !ok || len(subs) == 0 {
+			return nil, schemaError(ptr, kw+" must be a non-empty array")
+		}
+		for i, sub := range subs {
+			node, err := c.compile(sub, ptr+"/"+kw+"/"+strconv.Itoa(i))
+			if err != nil {
+				return nil, err
+			}
+			*dst = append(*dst, node)
+		}
+	}
+	return n, nil
+}
+
+// resolve compiles the target of a $ref, only JSON pointers within the
+// document are supported.
+func (c *schemaCompiler) resolve(ref string) (*schemaNode, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, errors.New("invalid schema: unsupported $ref " + ref)
+	}
+	ptr, err := url.PathUnescape(ref)
+	if err != nil {
+		return nil, errors.New("invalid schema: invalid $ref " + ref)
+	}
+	if n, ok := c.nodes[ptr]; ok {
+		return n, nil
+	}
+
+	raw := c.doc
+	if ptr != "#" {
+		if !strings.HasPrefix(ptr, "#/") {
+			return nil, errors.New("invalid schema: unsupported $ref " + ref)
+		}
+		for _, token := range strings.Split(ptr[2:], "/") {
+			token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+			switch node := raw.(type) {
+			case map[string]interface{}:
+				raw = node[token]
+			case []interface{}:
+				i, err := strconv.Atoi(token)
+				if err != nil || i < 0 || i >= len(node) {
+					raw = nil
+				} else {
+					raw = node[i]
+				}
+			default:
+				raw = nil
+			}
+			if raw == nil {
+				return nil, errors.New("invalid schema: $ref " + ref + " not found")
+			}
+		}
+	}
+	return c.compile(raw, ptr)
+}
+
+func escapePointer(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+func schemaError(ptr, msg string) error {
+	return errors.New("invalid schema at " + ptr + ": " + msg)
+}
+
+// ValidateSchema validate data against a compiled JSON Schema. data is a
+// decoded JSON value (map[string]interface{}, []interface{}, string, float64,
+// json.Number, bool or nil), a JSON document as []byte or json.RawMessage,
+// or form values as map[string]string, whose values are converted to the
+// number, integer or boolean types of the schema. The validation errors of
+// all fields are returned as Errors, Path locating the field.
+func (v *Validator) ValidateSchema(data interface{}, s *Schema) error {
+	w := &schemaWalker{v: v}
+	if raw, ok := data.(json.RawMessage); ok {
+		data = []byte(raw)
+	}
+	switch data.(type) {
+	case []byte:
+		dec := json.NewDecoder(bytes.NewReader(data.([]byte)))
+		dec.UseNumber()
+		var doc interface{}
+		if err := dec.Decode(&doc); err != nil {
+			return v.error(MsgDataType, "", "", "types", "JSON")
+		}
+		// A document is a single JSON value.
+		if _, err := dec.Token(); err != io.EOF {
+			return v.error(MsgDataType, "", "", "types", "JSON")
+		}
+		data = doc
+	case map[string]string:
+		fields := v.trim(data.(map[string]string))
+		obj := make(map[string]interface{}, len(fields))
+		for key, val := range fields {
+			obj[key] = val
+		}
+		data = obj
+		w.form = true
+	}
+	w.validate(s.root, data, "", "")
+	return w.errs.err()
+}
+
+// ValidateSchemap validate data against a JSON Schema with custom error info.
+// if err != nil will panic.
+func (v *Validator) ValidateSchemap(data interface{}, s *Schema, code int, message string) {
+	if err := v.ValidateSchema(data, s); err != nil {
+		v.panicError(err, code, message)
+	}
+}
+
+// schemaWalker collects the errors of a value, form is set when the
+// properties of the root object are strings to convert.
+type schemaWalker struct {
+	v     *Validator
+	errs  Errors
+	form  bool
+	depth int
+}
+
+func (w *schemaWalker) fail(id, key, path string, value interface{}, params ...string) {
+	e := w.v.FieldError(id, key, schemaText(value), params...)
+	e.Path = path
+	w.errs = append(w.errs, e)
+}
+
+// matches reports whether value is valid against n, without collecting errors.
+func (w *schemaWalker) matches(n *schemaNode, value interface{}, key, path string) bool {
+	sub := &schemaWalker{v: w.v, form: w.form, depth: w.depth}
+	sub.validate(n, value, key, path)
+	return len(sub.errs) == 0
+}
+
+func (w *schemaWalker) validate(n *schemaNode, value interface{}, key, path string) {
+	w.depth++
+	defer func() { w.depth-- }()
+	if n.reject || w.depth > maxSchemaDepth {
+		w.fail(MsgNotAllowed, key, path, value)
+		return
+	}
+	if n.ref != nil {
+		w.validate(n.ref, value, key, path)
+	}
+
+	if len(n.types) > 0 {
+		ok := false
+		for _, t := range n.types {
+			if schemaType(value, t) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			id := MsgFieldType
+			if path == "" {
+				// The document itself has no field to name.
+				id = MsgDataType
+			}
+			w.fail(id, key, path, value, "types", strings.Join(n.types, " or "))
+			return
+		}
+	}
+
+	if n.enum != nil && !schemaContains(n.enum, value) {
+		w.fail(MsgInvalid, key, path, value)
+	}
+	if n.constant != nil && !schemaEqual(n.constant[0], value) {
+		w.fail(MsgInvalid, key, path, value)
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		w.object(n, val, path)
+	case []interface{}:
+		w.array(n, val, key, path)
+	case string:
+		w.string(n, val, key, path)
+	default:
+		if f, ok := schemaNumber(value); ok {
+			w.number(n, f, key, path, value)
+		}
+	}
+
+	for _, sub := range n.allOf {
+		w.validate(sub, value, key, path)
+	}
+	if n.anyOf != nil {
+		ok := false
+		for _, sub := range n.anyOf {
+			if w.matches(sub, value, key, path) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			w.fail(MsgInvalid, key, path, value)
+		}
+	}
+	if n.oneOf != nil {
+		count := 0
+		for _, sub := range n.oneOf {
+			if w.matches(sub, value, key, path) {
+				count++
+			}
+		}
+		if count != 1 {
+			w.fail(MsgInvalid, key, path, value)
+		}
+	}
+}
+
+func (w *schemaWalker) object(n *schemaNode, obj map[string]interface{}, path string) {
+	for _, name := range n.required {
+		if _, ok := obj[name]; !ok {
+			w.fail(MsgRequired, name, joinPath(path, name), nil)
+		}
+	}
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if sub, ok := n.properties[name]; ok {
+			value := obj[name]
+			if s, ok := value.(string); ok && w.form && path == "" {
+				value = formValue(s, sub.typeList())
+			}
+			w.validate(sub, value, name, joinPath(path, name))
+		} else if n.noExtra {
+			w.fail(MsgNotAllowed, name, joinPath(path, name), obj[name])
+		}
+	}
+}
+
+func (w *schemaWalker) array(n *schemaNode, items []interface{}, key, path string) {
+	if n.minItems != nil && len(items) < *n.minItems {
+		w.fail(MsgMinItems, key, path, items, intBounds(n.minItems, n.maxItems)...)
+	}
+	if n.maxItems != nil && len(items) > *n.maxItems {
+		w.fail(MsgMaxItems, key, path, items, intBounds(n.minItems, n.maxItems)...)
+	}
+	if n.items == nil {
+		return
+	}
+	for i, item := range items {
+		w.validate(n.items, item, key, path+"["+strconv.Itoa(i)+"]")
+	}
+}
+
+func (w *schemaWalker) string(n *schemaNode, s, key, path string) {
+	length := utf8.RuneCountInString(s)
+	if n.minLength != nil && length < *n.minLength {
+		w.fail(MsgMinLength, key, path, s, intBounds(n.minLength, n.maxLength)...)
+	}
+	if n.maxLength != nil && length > *n.maxLength {
+		w.fail(MsgMaxLength, key, path, s, intBounds(n.minLength, n.maxLength)...)
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		w.fail(MsgPattern, key, path, s)
+	}
+	if fn, ok := SchemaFormats[n.format]; ok && !fn(s) {
+		w.fail(MsgRule, key, path, s, "rule", n.format)
+	}
+}
+
+func (w *schemaWalker) number(n *schemaNode, f float64, key, path string, value interface{}) {
+	if n.minimum != nil && f < *n.minimum {
+		w.fail(MsgMin, key, path, value, "min", formatFloat(*n.minimum))
+	}
+	if n.exclMin != nil && f <= *n.exclMin {
+		w.fail(MsgMin, key, path, value, "min", formatFloat(*n.exclMin))
+	}
+	if n.maximum != nil && f > *n.maximum {
+		w.fail(MsgMax, key, path, value, "max", formatFloat(*n.maximum))
+	}
+	if n.exclMax != nil && f >= *n.exclMax {
+		w.fail(MsgMax, key, path, value, "max", formatFloat(*n.exclMax))
+	}
+}
+
+// typeList returns the types of n, or of its $ref target if it has none.
+func (n *schemaNode) typeList() []string {
+	if n.types == nil && n.ref != nil {
+		return n.ref.typeList()
+	}
+	return n.types
+}
+
+// formValue converts a form value to the first number, integer or boolean
+// type of the schema it parses as, otherwise it stays a string.
+func formValue(s string, types []string) interface{} {
+	for _, t := range types {
+		switch t {
+		case "string":
+			return s
+		case "number", "integer":
+			if _, err := strconv.ParseFloat(s, 64); err == nil {
+				return json.Number(s)
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(s); err == nil {
+				return b
+			}
+		}
+	}
+	return s
+}
+
+// schemaType reports whether value is of the JSON Schema type t.
+func schemaType(value interface{}, t string) bool {
+	switch t {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := schemaNumber(value)
+		return ok
+	case "integer":
+		f, ok := schemaNumber(value)
+		return ok && f == math.Trunc(f) && !math.IsInf(f, 0)
+	}
+	return false
+}
+
+// schemaNumber returns the value of a JSON number.
+func schemaNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// schemaEqual reports whether two JSON values are equal, numbers by value.
+func schemaEqual(a, b interface{}) bool {
+	if x, ok := schemaNumber(a); ok {
+		y, ok := schemaNumber(b)
+		return ok && x == y
+	}
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, xv := range x {
+			yv, ok := y[k]
+			if !ok || !schemaEqual(xv, yv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !schemaEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+func schemaContains(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if schemaEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaText returns the text of a value for errors, strings as is and
+// other values as JSON.
+func schemaText(value interface{}) string {
+	switch val := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	}
+	b, _ := json.Marshal(value)
+	return string(b)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func intBounds(min, max *int) []string {
+	var params []string
+	if min != nil {
+		params = append(params, "min", strconv.Itoa(*min))
+	}
+	if max != nil {
+		params = append(params, "max", strconv.Itoa(*max))
+	}
+	return params
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// schemaRuleFormats maps named rules onto the JSON Schema formats of SchemaFormats.
+var schemaRuleFormats = map[string]string{
+	"email":    "email",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"url":      "uri",
+	"hostname": "hostname",
+	"rfc3339":  "date-time",
+	"uuid":     "uuid",
+}
+
+// schemaRulePatterns maps named rules onto the patterns they check.
+var schemaRulePatterns = map[string]string{
+	"numeric":      PatternNumeric,
+	"hexadecimal":  PatternHexadecimal,
+	"alpha":        PatternAlpha,
+	"alphanumeric": PatternAlphanumeric,
+	"latitude":     PatternLatitude,
+	"longitude":    PatternLongitude,
+	"base64":       PatternBase64,
+	"semver":       PatternSemver,
+	"hexcolor":     PatternHexColor,
+	"rgbcolor":     PatternRGBColor,
+	"rgbacolor":    PatternRGBAColor,
+}
+
+// RuleSchema returns the JSON Schema of a field validated by a rule string,
+// e.g. "int|min:0|max:200" gives {"type":"integer","minimum":0,"maximum":200}.
+// The schema marshals with encoding/json and compiles with CompileSchema.
+// Checks JSON Schema cannot express, such as expr, hash or custom rules, are
+// listed in the "x-rules" keyword. required is a property of the enclosing
+// object, see RulesSchema.
+func RuleSchema(rule string) (map[string]interface{}, error) {
+	rs, err := parseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	return rs.schema(), nil
+}
+
+// RulesSchema returns the JSON Schema of an object whose fields are
+// validated by rule strings keyed by field name, as with ValidateRules.
+func RulesSchema(rules map[string]string) (map[string]interface{}, error) {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sets := make([]structRule, 0, len(keys))
+	for _, key := range keys {
+		rs, err := parseRule(rules[key])
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, structRule{key: key, set: rs})
+	}
+	return objectSchema(sets, nil), nil
+}
+
+// StructSchema returns the JSON Schema of a struct from the rule strings in
+// its `valid` tags, as with ValidateStruct. s is a struct, a pointer to
+// one or a nil pointer of its type; the value types default to the Go types
+// of the fields.
+func StructSchema(s interface{}) (map[string]interface{}, error) {
+	rt := reflect.TypeOf(s)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt == nil || rt.Kind() != reflect.Struct {
+		return nil, errors.New("data type invalid, must be struct or pointer to struct")
+	}
+
+	sfs, err := structFields(rt)
+	if err != nil {
+		return nil, err
+	}
+	sets := make([]structRule, 0, len(sfs))
+	bools := make(map[string]bool)
+	for _, f := range sfs {
+		bools[f.key] = f.kind == reflect.Bool
+		sets = append(sets, f.structRule)
+	}
+	return objectSchema(sets, bools), nil
+}
+
+// objectSchema returns the schema of an object with the fields of rules,
+// bools are the keys of boolean fields.
+func objectSchema(rules []structRule, bools map[string]bool) map[string]interface{} {
+	props := make(map[string]interface{}, len(rules))
+	var required []string
+	for _, r := range rules {
+		schema := r.set.schema()
+		if bools[r.key] {
+			schema["type"] = "boolean"
+		}
+		props[r.key] = schema
+		if r.set.Required {
+			required = append(required, r.key)
+		}
+	}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}
+	if required != nil {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// schema returns the JSON Schema of the rule set.
+func (rs *Rule) schema() map[string]interface{} {
+	schema := make(map[string]interface{})
+	minKw, maxKw := "minLength", "maxLength"
+	switch rs.Kind {
+	case "int", "int64":
+		schema["type"] = "integer"
+		minKw, maxKw = "minimum", "maximum"
+	case "float":
+		schema["type"] = "number"
+		minKw, maxKw = "minimum", "maximum"
+	default:
+		schema["type"] = "string"
+	}
+	if rs.Min != "" {
+		schema[minKw] = schemaBound(rs.Min, rs.Kind != "float", true)
+	}
+	if rs.Max != "" {
+		schema[maxKw] = schemaBound(rs.Max, rs.Kind != "float", false)
+	}
+
+	if rs.In != nil {
+		enum := make([]interface{}, len(rs.In))
+		for i, val := range rs.In {
+			val = strings.TrimSpace(val)
+			enum[i] = val
+			if rs.Kind != "string" && rs.Kind != "" {
+				if f, err := strconv.ParseFloat(val, 64); err == nil {
+					enum[i] = f
+				}
+			}
+		}
+		schema["enum"] = enum
+	}
+
+	var patterns, rules []string
+	if rs.Pattern != "" {
+		patterns = append(patterns, rs.Pattern)
+	}
+	var allOf []interface{}
+	for _, c := range rs.Checks {
+		if format, ok := schemaRuleFormats[c.Name]; ok {
+			if _, ok := schema["format"]; !ok {
+				schema["format"] = format
+				continue
+			}
+			allOf = append(allOf, map[string]interface{}{"format": format})
+		} else if pattern, ok := schemaRulePatterns[c.Name]; ok {
+			patterns = append(patterns, pattern)
+		} else if c.Name == "ip" {
+			allOf = append(allOf, map[string]interface{}{"anyOf": []interface{}{
+				map[string]interface{}{"format": "ipv4"},
+				map[string]interface{}{"format": "ipv6"},
+			}})
+		} else if c.Param != "" {
+			rules = append(rules, c.Name+":"+c.Param)
+		} else {
+			rules = append(rules, c.Name)
+		}
+	}
+	for i, pattern := range patterns {
+		if i == 0 {
+			schema["pattern"] = pattern
+			continue
+		}
+		allOf = append(allOf, map[string]interface{}{"pattern": pattern})
+	}
+	if allOf != nil {
+		schema["allOf"] = allOf
+	}
+	if rs.Expr != nil {
+		rules = append(rules, "expr:"+rs.Expr.String())
+	}
+	if rules != nil {
+		schema["x-rules"] = rules
+	}
+	return schema
+}
+
+// schemaBound returns a rule bound as a JSON number, integer bounds are
+// those the rule engine compares with, see ruleInt.
+func schemaBound(bound string, integer, min bool) interface{} {
+	if integer {
+		n, _ := ruleInt(bound, min)
+		return n
+	}
+	f, _ := strconv.ParseFloat(bound, 64)
+	return f
+}
@@ -0,0 +1,101 @@
+package vvalidator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	s, err := CompileSchema([]byte(`{
+		"type": "object",
+		"required": ["id", "email"],
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"email": {"type": "string", "format": "email", "maxLength": 50},
+			"status": {"enum": ["active", "disabled"]},
+			"score": {"type": "number", "exclusiveMaximum": 100},
+			"addresses": {"type": "array", "maxItems": 2, "items": {"$ref": "#/$defs/address"}}
+		},
+		"$defs": {
+			"address": {
+				"type": "object",
+				"required": ["city"],
+				"properties": {
+					"city": {"type": "string", "minLength": 1},
+					"zip": {"oneOf": [{"type": "string", "pattern": "^[0-9]{5}$"}, {"type": "null"}]}
+				}
+			}
+		}
+	}`))
+	equal(t, nil, err)
+
+	equal(t, nil, ValidateSchema([]byte(`{"id": 1, "email": "a@b.com", "addresses": [{"city": "Paris", "zip": null}]}`), s))
+	equal(t, nil, ValidateSchema(map[string]string{"id": "1", "email": "a@b.com", "score": "99.5"}, s))
+	err = ValidateSchema([]byte(`{"id": 1.5, "status": "x", "score": 100, "addresses": [{"city": "Paris"}, {"zip": "1"}]}`), s)
+	equal(t, "email is required; city is required; zip is invalid; id must be of type integer; "+
+		"score is too big (maximum is 100); status is invalid", err.Error())
+	equal(t, "addresses[1].city", err.(Errors)[1].Path)
+	equal(t, "email must be a valid email", ValidateSchema(map[string]string{"id": "1", "email": "a"}, s).Error())
+	equal(t, "data type invalid, must be object", ValidateSchema([]byte(`[]`), s).Error())
+	equal(t, "data type invalid, must be JSON", ValidateSchema([]byte(`{"id": 1, "email": "a@b.com"} {"id": "x"}`), s).Error())
+	equal(t, "data type invalid, must be JSON", ValidateSchema([]byte(`{"id": 1, "email": "a@b.com"}]`), s).Error())
+	equal(t, nil, ValidateSchema([]byte(" {\"id\": 1, \"email\": \"a@b.com\"}\n"), s))
+
+	_, err = CompileSchema([]byte(`{"$ref": "other.json#/a"}`))
+	equal(t, "invalid schema: unsupported $ref other.json#/a", err.Error())
+	_, err = CompileSchema([]byte(`{"properties": {"a": {"minLength": -1}}}`))
+	equal(t, "invalid schema at #/properties/a: minLength must be a non-negative integer", err.Error())
+}
+
+func TestRulesSchema(t *testing.T) {
+	rules := map[string]string{
+		"uid":      "required|int|min:1|max:200",
+		"delta":    "int|min:-1|max:9007199254740993",
+		"currency": "in:USD,EUR",
+		"email":    "email|max:50",
+		"code":     "alpha|pattern:^[A-Z]+$",
+		"digest":   "hash:md5",
+	}
+	schema, err := RulesSchema(rules)
+	equal(t, nil, err)
+	b, err := json.Marshal(schema)
+	equal(t, nil, err)
+	equal(t, `{"properties":{"code":{"allOf":[{"pattern":"^[a-zA-Z]+$"}],"pattern":"^[A-Z]+$","type":"string"},`+
+		`"currency":{"enum":["USD","EUR"],"type":"string"},"delta":{"maximum":9007199254740993,"minimum":-1,"type":"integer"},"digest":{"type":"string","x-rules":["hash:md5"]},`+
+		`"email":{"format":"email","maxLength":50,"type":"string"},"uid":{"maximum":200,"minimum":1,"type":"integer"}},`+
+		`"required":["uid"],"type":"object"}`, string(b))
+
+	s, err := CompileSchema(b)
+	equal(t, nil, err)
+	for _, params := range []map[string]string{
+		{"uid": "10", "currency": "USD", "email": "a@b.com", "code": "AB"},
+		{"uid": "300"},
+		{"uid": "1", "delta": "-2"},
+		{"currency": "CNY", "code": "ab"},
+		{"uid": "1", "email": "a"},
+	} {
+		equal(t, ValidateRules(params, rules) == nil, ValidateSchema(params, s) == nil)
+	}
+
+	type user struct {
+		ID     int64   `json:"id" valid:"required|min:1"`
+		Admin  bool    `json:"admin" valid:""`
+		Score  float64 `json:"score" valid:"max:100"`
+		Status *string `valid:"in:active,disabled"`
+		Note   string  `json:"note"`
+		secret string  `valid:"required"`
+	}
+	schema, err = StructSchema((*user)(nil))
+	equal(t, nil, err)
+	b, _ = json.Marshal(schema)
+	equal(t, `{"properties":{"Status":{"enum":["active","disabled"],"type":"string"},"admin":{"type":"boolean"},`+
+		`"id":{"minimum":1,"type":"integer"},"score":{"maximum":100,"type":"number"}},"required":["id"],"type":"object"}`, string(b))
+
+	// Untagged and unexported fields are left out by both.
+	s, err = CompileSchema(b)
+	equal(t, nil, err)
+	for _, u := range []user{{ID: 1, Note: "x"}, {ID: 0}, {ID: 1, Score: 101}} {
+		params := map[string]interface{}{"id": u.ID, "admin": u.Admin, "score": u.Score, "note": u.Note}
+		equal(t, ValidateStruct(&u) == nil, ValidateSchema(params, s) == nil)
+	}
+}
@@ -0,0 +1,249 @@
+package vvalidator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Resolver resolves host names to IP addresses, *net.Resolver implements it.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// SSRFGuard checks that the URLs and hosts given by users, e.g. webhooks,
+// only reach public addresses. Host names are resolved and all their
+// addresses must be public. The zero value is ready to use.
+type SSRFGuard struct {
+	// Resolver resolves host names, nil means net.DefaultResolver.
+	Resolver Resolver
+	// URLOptions are the options of the URLs checked by CheckURL, a scheme
+	// is always required.
+	URLOptions URLOptions
+	// Allow are the networks allowed anyway, e.g. an internal service.
+	Allow []*net.IPNet
+}
+
+// blockedNets are the networks that are not publicly routable.
+var blockedNets = parseCIDRs(
+	"0.0.0.0/8",       // "this" network, unspecified
+	"10.0.0.0/8",      // private, RFC 1918
+	"100.64.0.0/10",   // carrier-grade NAT, RFC 6598
+	"127.0.0.0/8",     // loopback
+	"169.254.0.0/16",  // link-local, cloud metadata services
+	"172.16.0.0/12",   // private, RFC 1918
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // documentation
+	"192.88.99.0/24",  // 6to4 relay anycast
+	"192.168.0.0/16",  // private, RFC 1918
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"224.0.0.0/4",     // multicast
+	"240.0.0.0/4",     // reserved, broadcast
+	"::/96",           // unspecified, loopback, IPv4-compatible
+	"100::/64",        // discard
+	"2001::/23",       // IETF protocol assignments
+	"2001:db8::/32",   // documentation
+	"fc00::/7",        // unique local
+	"fe80::/10",       // link-local
+	"fec0::/10",       // site-local
+	"ff00::/8",        // multicast
+)
+
+// metadataHosts are the host names of cloud metadata services.
+var metadataHosts = map[string]bool{
+	"metadata":                 true,
+	"metadata.google.internal": true,
+	"metadata.goog":            true,
+	"instance-data":            true,
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = n
+	}
+	return nets
+}
+
+// IsPublicIP checks if the string is a publicly routable IP address. IPv4
+// addresses embedded in IPv6 ones (IPv4-mapped, NAT64, 6to4) are checked,
+// and IPv4 addresses may be in the decimal, octal or hexadecimal notations
+// of inet_aton, e.g. "2130706433" or "0x7f.1" for 127.0.0.1.
+func IsPublicIP(str string) bool {
+	ip := parseIP(str)
+	return ip != nil && isPublicIP(ip)
+}
+
+// parseIP parses an IP address, IPv4 addresses in any inet_aton notation.
+func parseIP(str string) net.IP {
+	if ip := net.ParseIP(str); ip != nil {
+		return ip
+	}
+	return parseInetAton(str)
+}
+
+// parseInetAton parses the IPv4 notations of inet_aton: 1 to 4 decimal,
+// octal (leading 0) or hexadecimal (leading 0x) numbers separated by
+// dots, the last one filling the remaining bytes.
+func parseInetAton(str string) net.IP {
+	parts := strings.Split(str, ".")
+	if len(parts) > 4 {
+		return nil
+	}
+	var ip uint64
+	for i, part := range parts {
+		base, digits := 10, part
+		switch {
+		case len(part) > 1 && (part[:2] == "0x" || part[:2] == "0X"):
+			base, digits = 16, part[2:]
+		case len(part) > 1 && part[0] == '0':
+			base, digits = 8, part[1:]
+		}
+		if digits == "" && base != 16 {
+			digits = "0"
+		}
+		n, err := strconv.ParseUint(digits, base, 32)
+		if err != nil || digits == "" {
+			return nil
+		}
+		if i < len(parts)-1 {
+			if n > 0xff {
+				return nil
+			}
+			ip |= n << uint(8*(3-i))
+		} else {
+			if n >= 1<<uint(8*(4-i)) {
+				return nil
+			}
+			ip |= n
+		}
+	}
+	return net.IPv4(byte(ip>>24), byte(ip>>16), byte(ip>>8), byte(ip))
+}
+
+func isPublicIP(ip net.IP) bool {
+	if v4 := embeddedIPv4(ip); v4 != nil {
+		ip = v4
+	}
+	for _, n := range blockedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// embeddedIPv4 returns the IPv4 address of IPv4-mapped, NAT64 and 6to4
+// IPv6 addresses, or nil.
+func embeddedIPv4(ip net.IP) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		return v4
+	}
+	ip = ip.To16()
+	switch {
+	case ip == nil:
+		return nil
+	case ip[0] == 0x00 && ip[1] == 0x64 && ip[2] == 0xff && ip[3] == 0x9b && isZero(ip[4:12]): // 64:ff9b::/96
+		return net.IP(ip[12:16])
+	case ip[0] == 0x20 && ip[1] == 0x02: // 2002::/16
+		return net.IP(ip[2:6])
+	}
+	return nil
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// allowed reports whether the guard lets a connection reach ip.
+func (g *SSRFGuard) allowed(ip net.IP) bool {
+	for _, n := range g.Allow {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return isPublicIP(ip)
+}
+
+// CheckURL parses an URL with the guard URLOptions and checks its host
+// with CheckHost.
+func (g *SSRFGuard) CheckURL(ctx context.Context, rawurl string) (*url.URL, error) {
+	opts := g.URLOptions
+	opts.RequireScheme = true
+	u, err := ParseURL(rawurl, opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := g.CheckHost(ctx, u.Hostname()); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// CheckHost checks that a host name or IP address only reaches allowed
+// addresses, and returns them. Connect to the returned addresses, or set
+// Control as the dialer Control, so that the DNS answer can't change in
+// between.
+func (g *SSRFGuard) CheckHost(ctx context.Context, host string) ([]net.IP, error) {
+	host = strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"), ".")
+	if host == "" {
+		return nil, errors.New("unsafe host: empty host")
+	}
+	if metadataHosts[strings.ToLower(host)] {
+		return nil, errors.New("unsafe host " + host + ": metadata service")
+	}
+	if ip := parseIP(host); ip != nil {
+		if !g.allowed(ip) {
+			return nil, errors.New("unsafe host " + host + ": address " + ip.String() + " is not public")
+		}
+		return []net.IP{ip}, nil
+	}
+
+	resolver := g.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New("unsafe host " + host + ": no address")
+	}
+	ips := make([]net.IP, len(addrs))
+	for i, addr := range addrs {
+		if !g.allowed(addr.IP) {
+			return nil, errors.New("unsafe host " + host + ": address " + addr.IP.String() + " is not public")
+		}
+		ips[i] = addr.IP
+	}
+	return ips, nil
+}
+
+// Control checks the address a connection is made to, use it as the
+// net.Dialer Control of the HTTP client sending requests to checked URLs.
+func (g *SSRFGuard) Control(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !g.allowed(ip) {
+		return errors.New("unsafe address " + address)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package vvalidator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+// fakeResolver resolves host names with a table.
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host " + host)
+	}
+	addrs := make([]net.IPAddr, len(ips))
+	for i, ip := range ips {
+		addrs[i] = net.IPAddr{IP: net.ParseIP(ip)}
+	}
+	return addrs, nil
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for ip, public := range map[string]bool{
+		"8.8.8.8":                true,
+		"2606:4700:4700::1111":   true,
+		"127.0.0.1":              false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"172.32.0.1":             true,
+		"192.168.1.1":            false,
+		"100.64.0.1":             false,
+		"100.100.100.200":        false,
+		"169.254.169.254":        false,
+		"224.0.0.1":              false,
+		"255.255.255.255":        false,
+		"0.0.0.0":                false,
+		"::":                     false,
+		"::1":                    false,
+		"fe80::1":                false,
+		"fd00:ec2::254":          false,
+		"ff02::1":                false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:7f00:1":          false,
+		"::ffff:8.8.8.8":         true,
+		"64:ff9b::a00:1":         false,
+		"64:ff9b::808:808":       true,
+		"2002:c0a8:101::1":       false,
+		"2130706433":             false,
+		"0x7f.1":                 false,
+		"0177.0.0.1":             false,
+		"0x7f000001":             false,
+		"127.1":                  false,
+		"017700000001":           false,
+		"134744072":              true,
+		"0x08.0x08.0x08.0x08":    true,
+		"256.1.1.1":              false,
+		"1.2.3.4.5":              false,
+		"example.com":            false,
+		"0x":                     false,
+		"08.1.1.1":               false,
+		"4294967296":             false,
+		"1.16777216":             false,
+		"2001:db8::1":            false,
+		"2001:4860:4860::8888":   true,
+		"[2001:4860:4860::8888]": false,
+		"1.2.3.256":              false,
+		"0.0.0.0.":               false,
+		"":                       false,
+		"0":                      false,
+		"192.0.2.1":              false,
+		"198.18.0.1":             false,
+		"203.0.113.9":            false,
+		"100::1":                 false,
+		"fec0::1":                false,
+		"::127.0.0.1":            false,
+		"1.1.1.1":                true,
+		"0xffffffff":             false,
+		"1.0x10000":              true,
+	} {
+		if IsPublicIP(ip) != public {
+			t.Errorf("IsPublicIP(%q) != %v", ip, public)
+		}
+	}
+}
+
+func TestSSRFGuard(t *testing.T) {
+	_, internal, _ := net.ParseCIDR("10.1.2.0/24")
+	g := &SSRFGuard{
+		Resolver: fakeResolver{
+			"hooks.example.com":    {"93.184.216.34", "2606:2800:220:1::1"},
+			"rebind.example.com":   {"93.184.216.34", "127.0.0.1"},
+			"internal.example.com": {"10.1.2.3"},
+			"empty.example.com":    {},
+			"v6.example.com":       {"::ffff:169.254.169.254"},
+		},
+		URLOptions: URLOptions{Schemes: []string{"https"}},
+		Allow:      []*net.IPNet{internal},
+	}
+	ctx := context.Background()
+
+	u, err := g.CheckURL(ctx, "https://hooks.example.com/in")
+	equal(t, nil, err)
+	equal(t, "hooks.example.com", u.Host)
+	ips, err := g.CheckHost(ctx, "hooks.example.com")
+	equal(t, nil, err)
+	equal(t, 2, len(ips))
+	ips, err = g.CheckHost(ctx, "[2606:4700:4700::1111]")
+	equal(t, nil, err)
+	equal(t, "2606:4700:4700::1111", ips[0].String())
+	_, err = g.CheckHost(ctx, "internal.example.com")
+	equal(t, nil, err)
+
+	for rawurl, msg := range map[string]string{
+		"https://rebind.example.com/":      "unsafe host rebind.example.com: address 127.0.0.1 is not public",
+		"https://v6.example.com/":          "unsafe host v6.example.com: address 169.254.169.254 is not public",
+		"https://empty.example.com/":       "unsafe host empty.example.com: no address",
+		"https://unknown.example.com/":     "no such host unknown.example.com",
+		"https://127.0.0.1/":               "unsafe host 127.0.0.1: address 127.0.0.1 is not public",
+		"https://[::ffff:127.0.0.1]/":      "unsafe host ::ffff:127.0.0.1: address 127.0.0.1 is not public",
+		"https://2130706433/":              "unsafe host 2130706433: address 127.0.0.1 is not public",
+		"https://0x7f.1/":                  "unsafe host 0x7f.1: address 127.0.0.1 is not public",
+		"https://169.254.169.254/latest/":  "unsafe host 169.254.169.254: address 169.254.169.254 is not public",
+		"https://metadata.google.internal": "unsafe host metadata.google.internal: metadata service",
+		"http://hooks.example.com/":        "invalid URL: scheme http is not allowed",
+		"hooks.example.com/":               "invalid URL: missing scheme",
+	} {
+		_, err := g.CheckURL(ctx, rawurl)
+		if err == nil {
+			t.Errorf("%s: expected error %s", rawurl, msg)
+		} else {
+			equal(t, msg, err.Error())
+		}
+	}
+
+	equal(t, nil, g.Control("tcp", "93.184.216.34:443", nil))
+	equal(t, nil, g.Control("tcp", "10.1.2.3:443", nil))
+	equal(t, "unsafe address [::1]:443", g.Control("tcp6", "[::1]:443", nil).Error())
+}
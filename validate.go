@@ -1,7 +1,6 @@
 package vvalidator
 
 import (
-	"errors"
 	"regexp"
 	"strconv"
 	"strings"
@@ -9,13 +8,13 @@ import (
 )
 
 // ValidateInt validate 32 bit integer
-func ValidateInt(data interface{}, key string, min, max int, def ...int) (int, error) {
+func (v *Validator) ValidateInt(data interface{}, key string, min, max int, def ...int) (int, error) {
 	var defVal interface{}
 	ldef := len(def)
 	if ldef > 0 {
 		defVal = def[0]
 	}
-	val, err := checkExist(data, key, defVal)
+	val, err := v.checkExist(data, key, defVal)
 	if err != nil {
 		return 0, err
 	}
@@ -26,55 +25,55 @@ func ValidateInt(data interface{}, key string, min, max int, def ...int) (int, e
 	case string:
 		value := val.(string)
 		if !IsInt(value) {
-			if ldef == 0 {
-				return 0, errors.New(key + " must be an integer")
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgInt, key, value)
 			}
 			return def[0], nil
 		}
 
-		v, err := strconv.Atoi(value)
+		n, err := strconv.Atoi(value)
 		if err != nil {
-			if ldef == 0 {
-				return 0, errors.New(key + " must be an integer")
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgInt, key, value)
 			}
 			return def[0], nil
 		}
-		if min != -1 && v < min {
-			if ldef == 0 {
-				return 0, errors.New(key + " is too small (minimum is " + strconv.Itoa(min) + ")")
+		if min != -1 && n < min {
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgMin, key, value, bounds(strconv.Itoa(min), strconv.Itoa(max))...)
 			}
 			return def[0], nil
 		}
-		if max != -1 && v > max {
-			if ldef == 0 {
-				return 0, errors.New(key + " is too big (maximum is " + strconv.Itoa(max) + ")")
+		if max != -1 && n > max {
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgMax, key, value, bounds(strconv.Itoa(min), strconv.Itoa(max))...)
 			}
 			return def[0], nil
 		}
-		return v, nil
+		return n, nil
 	default:
-		return 0, errors.New("type invalid, must be string or int")
+		return 0, v.error(MsgType, key, "", "types", "string or int")
 	}
 }
 
 // ValidateIntp Validate 32 bit integer with custom error info.
 // if err != nil will panic.
-func ValidateIntp(data interface{}, key string, min, max int, code int, message string, def ...int) int {
-	val, err := ValidateInt(data, key, min, max, def...)
+func (v *Validator) ValidateIntp(data interface{}, key string, min, max int, code int, message string, def ...int) int {
+	val, err := v.ValidateInt(data, key, min, max, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // ValidateInt64 Validate 64 bit integer.
-func ValidateInt64(data interface{}, key string, min, max int64, def ...int64) (int64, error) {
+func (v *Validator) ValidateInt64(data interface{}, key string, min, max int64, def ...int64) (int64, error) {
 	var defVal interface{}
 	ldef := len(def)
 	if ldef > 0 {
 		defVal = def[0]
 	}
-	val, err := checkExist(data, key, defVal)
+	val, err := v.checkExist(data, key, defVal)
 	if err != nil {
 		return 0, err
 	}
@@ -85,55 +84,55 @@ func ValidateInt64(data interface{}, key string, min, max int64, def ...int64) (
 	case string:
 		value := val.(string)
 		if !IsInt(value) {
-			if ldef == 0 {
-				return 0, errors.New(key + " must be a valid interger")
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgInt64, key, value)
 			}
 			return def[0], nil
 		}
 
-		v, err := strconv.ParseInt(value, 10, 64)
+		n, err := strconv.ParseInt(value, 10, 64)
 		if err != nil {
-			if ldef == 0 {
-				return 0, errors.New(key + " must be a valid interger")
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgInt64, key, value)
 			}
 			return def[0], nil
 		}
-		if min != -1 && v < min {
-			if ldef == 0 {
-				return 0, errors.New(key + " is too small (minimum is " + strconv.FormatInt(min, 10) + ")")
+		if min != -1 && n < min {
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgMin, key, value, bounds(strconv.FormatInt(min, 10), strconv.FormatInt(max, 10))...)
 			}
 			return def[0], nil
 		}
-		if max != -1 && v > max {
-			if ldef == 0 {
-				return 0, errors.New(key + " is too big (maximum is " + strconv.FormatInt(max, 10) + ")")
+		if max != -1 && n > max {
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgMax, key, value, bounds(strconv.FormatInt(min, 10), strconv.FormatInt(max, 10))...)
 			}
 			return def[0], nil
 		}
-		return v, nil
+		return n, nil
 	default:
-		return 0, errors.New("type invalid, must be string or int64")
+		return 0, v.error(MsgType, key, "", "types", "string or int64")
 	}
 }
 
 // ValidateInt64p Validate 64 bit integer with custom error info.
 // if err != nil will panic.
-func ValidateInt64p(data interface{}, key string, min, max int64, code int, message string, def ...int64) int64 {
-	val, err := ValidateInt64(data, key, min, max, def...)
+func (v *Validator) ValidateInt64p(data interface{}, key string, min, max int64, code int, message string, def ...int64) int64 {
+	val, err := v.ValidateInt64(data, key, min, max, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // ValidateFloat validate 64 bit float.
-func ValidateFloat(data interface{}, key string, min, max float64, def ...float64) (float64, error) {
+func (v *Validator) ValidateFloat(data interface{}, key string, min, max float64, def ...float64) (float64, error) {
 	var defVal interface{}
 	ldef := len(def)
 	if ldef > 0 {
 		defVal = def[0]
 	}
-	val, err := checkExist(data, key, defVal)
+	val, err := v.checkExist(data, key, defVal)
 	if err != nil {
 		return 0, err
 	}
@@ -144,54 +143,54 @@ func ValidateFloat(data interface{}, key string, min, max float64, def ...float6
 	case string:
 		value := val.(string)
 		if !IsFloat(value) {
-			if ldef == 0 {
-				return 0, errors.New(key + " must be a valid float64")
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgFloat, key, value)
 			}
 			return def[0], nil
 		}
 
-		v, err := strconv.ParseFloat(value, 64)
+		n, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			if ldef == 0 {
-				return 0, errors.New(key + " must be a valid float64")
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgFloat, key, value)
 			}
 			return def[0], nil
 		}
-		if min != -1 && v < min {
-			if ldef == 0 {
-				return 0, errors.New(key + " is too small (minimum is " + strconv.FormatFloat(min, 'f', -1, 64) + ")")
+		if min != -1 && n < min {
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgMin, key, value, bounds(strconv.FormatFloat(min, 'f', -1, 64), strconv.FormatFloat(max, 'f', -1, 64))...)
 			}
 			return def[0], nil
 		}
-		if max != -1 && v > max {
-			if ldef == 0 {
-				return 0, errors.New(key + " is too big (maximum is " + strconv.FormatFloat(max, 'f', -1, 64) + ")")
+		if max != -1 && n > max {
+			if ldef == 0 || v.Strict {
+				return 0, v.error(MsgMax, key, value, bounds(strconv.FormatFloat(min, 'f', -1, 64), strconv.FormatFloat(max, 'f', -1, 64))...)
 			}
 			return def[0], nil
 		}
-		return v, nil
+		return n, nil
 	default:
-		return 0, errors.New("type invalid, must be string or float64")
+		return 0, v.error(MsgType, key, "", "types", "string or float64")
 	}
 }
 
 // ValidateFloatp validate 64 bit float with custom error info.
 // if err != nil will panic.
-func ValidateFloatp(data interface{}, key string, min, max float64, code int, message string, def ...float64) float64 {
-	val, err := ValidateFloat(data, key, min, max, def...)
+func (v *Validator) ValidateFloatp(data interface{}, key string, min, max float64, code int, message string, def ...float64) float64 {
+	val, err := v.ValidateFloat(data, key, min, max, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // ValidateString validate string.
-func ValidateString(data interface{}, key string, min, max int, def ...string) (string, error) {
+func (v *Validator) ValidateString(data interface{}, key string, min, max int, def ...string) (string, error) {
 	var defVal interface{}
 	if len(def) > 0 {
 		defVal = def[0]
 	}
-	val, err := checkExist(data, key, defVal)
+	val, err := v.checkExist(data, key, defVal)
 	if err != nil {
 		return "", err
 	}
@@ -201,38 +200,38 @@ func ValidateString(data interface{}, key string, min, max int, def ...string) (
 		return def[0], nil
 	}
 	if min != -1 && length < min {
-		return "", errors.New(key + " is too short (minimum is " + strconv.Itoa(min) + " characters)")
+		return "", v.error(MsgMinLength, key, val.(string), bounds(strconv.Itoa(min), strconv.Itoa(max))...)
 	}
 	if max != -1 && length > max {
-		return "", errors.New(key + " is too long (maximum is " + strconv.Itoa(max) + " characters)")
+		return "", v.error(MsgMaxLength, key, val.(string), bounds(strconv.Itoa(min), strconv.Itoa(max))...)
 	}
 	return val.(string), nil
 }
 
 // ValidateStringp validate string with custom error info.
 // if err != nil will panic.
-func ValidateStringp(data interface{}, key string, min, max int, code int, message string, def ...string) string {
-	val, err := ValidateString(data, key, min, max, def...)
+func (v *Validator) ValidateStringp(data interface{}, key string, min, max int, code int, message string, def ...string) string {
+	val, err := v.ValidateString(data, key, min, max, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // ValidateStringWithPattern validate string with regexp pattern.
-func ValidateStringWithPattern(data interface{}, key, pattern string, def ...string) (string, error) {
+func (v *Validator) ValidateStringWithPattern(data interface{}, key, pattern string, def ...string) (string, error) {
 	var defVal interface{}
 	ldef := len(def)
 	if ldef > 0 {
 		defVal = def[0]
 	}
-	val, err := checkExist(data, key, defVal)
+	val, err := v.checkExist(data, key, defVal)
 	if err != nil {
 		return "", err
 	}
 	if !regexp.MustCompile(pattern).MatchString(val.(string)) {
-		if ldef == 0 {
-			return "", errors.New(key + " must be a valid string")
+		if ldef == 0 || v.Strict {
+			return "", v.error(MsgPattern, key, val.(string))
 		}
 		return def[0], nil
 	}
@@ -242,93 +241,93 @@ func ValidateStringWithPattern(data interface{}, key, pattern string, def ...str
 
 // ValidateStringWithPatternp validateStringWithPatternp validate string with regex pattern.
 // if err != nil will panic.
-func ValidateStringWithPatternp(data interface{}, key, pattern string, code int, message string, def ...string) string {
-	val, err := ValidateStringWithPattern(data, key, pattern, def...)
+func (v *Validator) ValidateStringWithPatternp(data interface{}, key, pattern string, code int, message string, def ...string) string {
+	val, err := v.ValidateStringWithPattern(data, key, pattern, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // ValidateEnumInt validate enum int.
-func ValidateEnumInt(data interface{}, key string, validValues []int, def ...int) (int, error) {
-	val, err := ValidateInt(data, key, -1, -1, def...)
+func (v *Validator) ValidateEnumInt(data interface{}, key string, validValues []int, def ...int) (int, error) {
+	val, err := v.ValidateInt(data, key, -1, -1, def...)
 	if err != nil {
 		return 0, nil
 	}
-	for _, v := range validValues {
-		if v == val {
+	for _, valid := range validValues {
+		if valid == val {
 			return val, nil
 		}
 	}
-	return 0, errors.New(key + " is invalid")
+	return 0, v.error(MsgInvalid, key, strconv.Itoa(val))
 }
 
 // ValidateEnumIntp validate enum int with custom error info.
 // if err != nil will panic.
-func ValidateEnumIntp(data interface{}, key string, validValues []int, code int, message string, def ...int) int {
-	val, err := ValidateEnumInt(data, key, validValues, def...)
+func (v *Validator) ValidateEnumIntp(data interface{}, key string, validValues []int, code int, message string, def ...int) int {
+	val, err := v.ValidateEnumInt(data, key, validValues, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // ValidateEnumInt64 validate enum int64
-func ValidateEnumInt64(data interface{}, key string, validValues []int64, def ...int64) (int64, error) {
-	val, err := ValidateInt64(data, key, -1, -1, def...)
+func (v *Validator) ValidateEnumInt64(data interface{}, key string, validValues []int64, def ...int64) (int64, error) {
+	val, err := v.ValidateInt64(data, key, -1, -1, def...)
 	if err != nil {
 		return 0, err
 	}
-	for _, v := range validValues {
-		if v == val {
+	for _, valid := range validValues {
+		if valid == val {
 			return val, nil
 		}
 	}
-	return 0, errors.New(key + " is invalid")
+	return 0, v.error(MsgInvalid, key, strconv.FormatInt(val, 10))
 }
 
 // ValidateEnumInt64p Validate enum int64 with panic.
 // if err != nil will panic.
-func ValidateEnumInt64p(data interface{}, key string, validValues []int64, code int, message string, def ...int64) int64 {
-	val, err := ValidateEnumInt64(data, key, validValues, def...)
+func (v *Validator) ValidateEnumInt64p(data interface{}, key string, validValues []int64, code int, message string, def ...int64) int64 {
+	val, err := v.ValidateEnumInt64(data, key, validValues, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // ValidateEnumString validate enum string
-func ValidateEnumString(data interface{}, key string, validValues []string, def ...string) (string, error) {
-	val, err := ValidateString(data, key, -1, -1, def...)
+func (v *Validator) ValidateEnumString(data interface{}, key string, validValues []string, def ...string) (string, error) {
+	val, err := v.ValidateString(data, key, -1, -1, def...)
 	if err != nil {
 		return "", nil
 	}
-	for _, v := range validValues {
-		if v == val {
+	for _, valid := range validValues {
+		if valid == val {
 			return val, nil
 		}
 	}
-	return "", errors.New(key + " is invalid")
+	return "", v.error(MsgInvalid, key, val)
 }
 
 // ValidateEnumStringp validate enum string with custom error info.
 // if err != nil will panic.
-func ValidateEnumStringp(data interface{}, key string, validValues []string, code int, message string, def ...string) string {
-	val, err := ValidateEnumString(data, key, validValues, def...)
+func (v *Validator) ValidateEnumStringp(data interface{}, key string, validValues []string, code int, message string, def ...string) string {
+	val, err := v.ValidateEnumString(data, key, validValues, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // ValidateSlice validate slice.
-func ValidateSlice(data interface{}, key, sep string, min, max int, def ...string) ([]string, error) {
+func (v *Validator) ValidateSlice(data interface{}, key, sep string, min, max int, def ...string) ([]string, error) {
 	var defVal interface{}
 	if len(def) > 0 {
 		defVal = def[0]
 	}
-	val, err := checkExist(data, key, defVal)
+	val, err := v.checkExist(data, key, defVal)
 	if err != nil {
 		return nil, err
 	}
@@ -336,26 +335,26 @@ func ValidateSlice(data interface{}, key, sep string, min, max int, def ...strin
 	vals := strings.Split(val.(string), sep)
 	length := len(vals)
 	if min != -1 && length < min {
-		return nil, errors.New(key + " is too short (minimum is " + strconv.Itoa(min) + " elements)")
+		return nil, v.error(MsgMinItems, key, val.(string), bounds(strconv.Itoa(min), strconv.Itoa(max))...)
 	}
 	if max != -1 && length > max {
-		return nil, errors.New(key + " is too long (maximum is " + strconv.Itoa(max) + " elements)")
+		return nil, v.error(MsgMaxItems, key, val.(string), bounds(strconv.Itoa(min), strconv.Itoa(max))...)
 	}
 	return vals, nil
 }
 
 // ValidateSlicep validate slice with custom error info.
 // if err != nil will panic.
-func ValidateSlicep(data interface{}, key, sep string, min, max int, code int, message string, def ...string) []string {
-	val, err := ValidateSlice(data, key, sep, min, max, def...)
+func (v *Validator) ValidateSlicep(data interface{}, key, sep string, min, max int, code int, message string, def ...string) []string {
+	val, err := v.ValidateSlice(data, key, sep, min, max, def...)
 	if err != nil {
-		panic(NewError(err.Error(), code, message))
+		v.panicError(err, code, message)
 	}
 	return val
 }
 
 // Chekc exist
-func checkExist(data interface{}, key string, def interface{}) (interface{}, error) {
+func (v *Validator) checkExist(data interface{}, key string, def interface{}) (interface{}, error) {
 	var val string
 	switch data.(type) {
 	case string:
@@ -365,17 +364,20 @@ func checkExist(data interface{}, key string, def interface{}) (interface{}, err
 			val = value
 		} else {
 			if def == nil {
-				return nil, errors.New(key + " is required")
+				return nil, v.error(MsgRequired, key, "")
 			}
 			return def, nil
 		}
 	default:
-		return nil, errors.New("data type invalid, must be string or map[string]string")
+		return nil, v.error(MsgDataType, key, "", "types", "string or map[string]string")
 	}
 
+	if v.Trim {
+		val = strings.TrimSpace(val)
+	}
 	if val == "" {
 		if def == nil {
-			return nil, errors.New(key + " can't be empty")
+			return nil, v.error(MsgEmpty, key, val)
 		}
 		return def, nil
 	}
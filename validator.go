@@ -0,0 +1,390 @@
+package vvalidator
+
+import (
+	"io"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Validator holds the configuration of validation, so that several
+// configurations can live in one program. The package level functions
+// use the Default validator.
+type Validator struct {
+	// Code is the code of the returned errors, 0 means DefaultCode.
+	Code int
+	// Messages overrides the message templates of the catalogs by message id,
+	// or by "key.id" for a single field, e.g. "uid.max".
+	Messages map[string]string
+	// Labels are the names shown for fields in messages by field key,
+	// the labels of the locale catalog take precedence.
+	Labels map[string]string
+	// Sensitive are the field keys whose values are redacted in errors.
+	Sensitive map[string]bool
+	// Strict makes invalid values an error even if a default value is given,
+	// defaults then only replace missing values. ValidateRules also rejects
+	// fields that have no rule.
+	Strict bool
+	// Trim removes leading and trailing white space from values before validating.
+	Trim bool
+	// Locale selects the message catalog, empty means "en".
+	Locale string
+
+	// reg is shared by the copies made with WithLocale.
+	reg *registry
+}
+
+type registry struct {
+	mu       sync.RWMutex
+	rules    map[string]RuleFunc
+	catalogs map[string]*Catalog
+}
+
+var std = New()
+
+// New returns a validator with the default configuration.
+func New() *Validator {
+	reg := &registry{
+		rules:    make(map[string]RuleFunc, len(builtinRules)),
+		catalogs: make(map[string]*Catalog, len(Catalogs)),
+	}
+	for name, fn := range builtinRules {
+		reg.rules[name] = fn
+	}
+	for locale, c := range Catalogs {
+		reg.catalogs[locale] = c.clone()
+	}
+	return &Validator{
+		Messages:  make(map[string]string),
+		Labels:    make(map[string]string),
+		Sensitive: make(map[string]bool),
+		reg:       reg,
+	}
+}
+
+// Default returns the validator used by the package level functions.
+func Default() *Validator {
+	return std
+}
+
+// RegisterRule registers a named rule for use in rule strings and struct tags.
+func (v *Validator) RegisterRule(name string, fn RuleFunc) {
+	v.reg.mu.Lock()
+	defer v.reg.mu.Unlock()
+	v.reg.rules[name] = fn
+}
+
+func (v *Validator) lookupRule(name string) (RuleFunc, bool) {
+	v.reg.mu.RLock()
+	defer v.reg.mu.RUnlock()
+	fn, ok := v.reg.rules[name]
+	return fn, ok
+}
+
+func (v *Validator) code() int {
+	if v.Code != 0 {
+		return v.Code
+	}
+	return DefaultCode
+}
+
+// trim returns the fields with their values trimmed if Trim is set.
+func (v *Validator) trim(fields map[string]string) map[string]string {
+	if !v.Trim {
+		return fields
+	}
+	trimmed := make(map[string]string, len(fields))
+	for key, val := range fields {
+		trimmed[key] = strings.TrimSpace(val)
+	}
+	return trimmed
+}
+
+// panicError panics with err as an Error of code,
+// its CustomMessage is the message template rendered like the catalog ones.
+// Errors panic as Errors, with the code and message set on every error.
+func (v *Validator) panicError(err error, code int, message string) {
+	if es, ok := err.(Errors); ok {
+		panicked := make(Errors, len(es))
+		for i, e := range es {
+			e.Code = code
+			e.CustomMessage = v.render(message, e)
+			panicked[i] = e
+		}
+		panic(panicked)
+	}
+	e, ok := err.(Error)
+	if !ok {
+		panic(NewError(err.Error(), code, message))
+	}
+	e.Code = code
+	e.CustomMessage = v.render(message, e)
+	panic(e)
+}
+
+// RegisterRule registers a named rule for use in rule strings and struct tags.
+func RegisterRule(name string, fn RuleFunc) {
+	std.RegisterRule(name, fn)
+}
+
+// ValidateInt validate 32 bit integer
+func ValidateInt(data interface{}, key string, min, max int, def ...int) (int, error) {
+	return std.ValidateInt(data, key, min, max, def...)
+}
+
+// ValidateIntp Validate 32 bit integer with custom error info.
+// if err != nil will panic.
+func ValidateIntp(data interface{}, key string, min, max int, code int, message string, def ...int) int {
+	return std.ValidateIntp(data, key, min, max, code, message, def...)
+}
+
+// ValidateInt64 Validate 64 bit integer.
+func ValidateInt64(data interface{}, key string, min, max int64, def ...int64) (int64, error) {
+	return std.ValidateInt64(data, key, min, max, def...)
+}
+
+// ValidateInt64p Validate 64 bit integer with custom error info.
+// if err != nil will panic.
+func ValidateInt64p(data interface{}, key string, min, max int64, code int, message string, def ...int64) int64 {
+	return std.ValidateInt64p(data, key, min, max, code, message, def...)
+}
+
+// ValidateFloat validate 64 bit float.
+func ValidateFloat(data interface{}, key string, min, max float64, def ...float64) (float64, error) {
+	return std.ValidateFloat(data, key, min, max, def...)
+}
+
+// ValidateFloatp validate 64 bit float with custom error info.
+// if err != nil will panic.
+func ValidateFloatp(data interface{}, key string, min, max float64, code int, message string, def ...float64) float64 {
+	return std.ValidateFloatp(data, key, min, max, code, message, def...)
+}
+
+// ValidateString validate string.
+func ValidateString(data interface{}, key string, min, max int, def ...string) (string, error) {
+	return std.ValidateString(data, key, min, max, def...)
+}
+
+// ValidateStringp validate string with custom error info.
+// if err != nil will panic.
+func ValidateStringp(data interface{}, key string, min, max int, code int, message string, def ...string) string {
+	return std.ValidateStringp(data, key, min, max, code, message, def...)
+}
+
+// ValidateStringWithPattern validate string with regexp pattern.
+func ValidateStringWithPattern(data interface{}, key, pattern string, def ...string) (string, error) {
+	return std.ValidateStringWithPattern(data, key, pattern, def...)
+}
+
+// ValidateStringWithPatternp validate string with regex pattern with custom error info.
+// if err != nil will panic.
+func ValidateStringWithPatternp(data interface{}, key, pattern string, code int, message string, def ...string) string {
+	return std.ValidateStringWithPatternp(data, key, pattern, code, message, def...)
+}
+
+// ValidateEnumInt validate enum int.
+func ValidateEnumInt(data interface{}, key string, validValues []int, def ...int) (int, error) {
+	return std.ValidateEnumInt(data, key, validValues, def...)
+}
+
+// ValidateEnumIntp validate enum int with custom error info.
+// if err != nil will panic.
+func ValidateEnumIntp(data interface{}, key string, validValues []int, code int, message string, def ...int) int {
+	return std.ValidateEnumIntp(data, key, validValues, code, message, def...)
+}
+
+// ValidateEnumInt64 validate enum int64
+func ValidateEnumInt64(data interface{}, key string, validValues []int64, def ...int64) (int64, error) {
+	return std.ValidateEnumInt64(data, key, validValues, def...)
+}
+
+// ValidateEnumInt64p Validate enum int64 with panic.
+// if err != nil will panic.
+func ValidateEnumInt64p(data interface{}, key string, validValues []int64, code int, message string, def ...int64) int64 {
+	return std.ValidateEnumInt64p(data, key, validValues, code, message, def...)
+}
+
+// ValidateEnumString validate enum string
+func ValidateEnumString(data interface{}, key string, validValues []string, def ...string) (string, error) {
+	return std.ValidateEnumString(data, key, validValues, def...)
+}
+
+// ValidateEnumStringp validate enum string with custom error info.
+// if err != nil will panic.
+func ValidateEnumStringp(data interface{}, key string, validValues []string, code int, message string, def ...string) string {
+	return std.ValidateEnumStringp(data, key, validValues, code, message, def...)
+}
+
+// ValidateSlice validate slice.
+func ValidateSlice(data interface{}, key, sep string, min, max int, def ...string) ([]string, error) {
+	return std.ValidateSlice(data, key, sep, min, max, def...)
+}
+
+// ValidateSlicep validate slice with custom error info.
+// if err != nil will panic.
+func ValidateSlicep(data interface{}, key, sep string, min, max int, code int, message string, def ...string) []string {
+	return std.ValidateSlicep(data, key, sep, min, max, code, message, def...)
+}
+
+// ValidateExpr validate that data satisfies the expression, key names the field
+// reported on failure.
+func ValidateExpr(data interface{}, key, expr string) error {
+	return std.ValidateExpr(data, key, expr)
+}
+
+// ValidateExprp validate expression with custom error info.
+// if err != nil will panic.
+func ValidateExprp(data interface{}, key, expr string, code int, message string) {
+	std.ValidateExprp(data, key, expr, code, message)
+}
+
+// ValidateRule validate a field with a rule string like "required|int|min:0|max:200".
+// data is a string or map[string]string as with the other validators.
+func ValidateRule(data interface{}, key, rule string) error {
+	return std.ValidateRule(data, key, rule)
+}
+
+// ValidateRulep validate a field with a rule string with custom error info.
+// if err != nil will panic.
+func ValidateRulep(data interface{}, key, rule string, code int, message string) {
+	std.ValidateRulep(data, key, rule, code, message)
+}
+
+// ValidateRules validate the fields of data with rule strings keyed by field name.
+// Fields are checked in key order and the first error is returned.
+func ValidateRules(data map[string]string, rules map[string]string) error {
+	return std.ValidateRules(data, rules)
+}
+
+// ValidateRulesp validate the fields of data with rule strings with custom error info.
+// if err != nil will panic.
+func ValidateRulesp(data map[string]string, rules map[string]string, code int, message string) {
+	std.ValidateRulesp(data, rules, code, message)
+}
+
+// ValidateStruct validate a struct with the rule strings in its `valid` tags.
+func ValidateStruct(s interface{}) error {
+	return std.ValidateStruct(s)
+}
+
+// ValidateStructp validate a struct with custom error info.
+// if err != nil will panic.
+func ValidateStructp(s interface{}, code int, message string) {
+	std.ValidateStructp(s, code, message)
+}
+
+// ValidateSchema validate data against a compiled JSON Schema.
+func ValidateSchema(data interface{}, s *Schema) error {
+	return std.ValidateSchema(data, s)
+}
+
+// ValidateSchemap validate data against a JSON Schema with custom error info.
+// if err != nil will panic.
+func ValidateSchemap(data interface{}, s *Schema, code int, message string) {
+	std.ValidateSchemap(data, s, code, message)
+}
+
+// ValidateBatch validate the CSV or JSON records of r with rule strings.
+func ValidateBatch(r io.Reader, rules map[string]string, opts BatchOptions) (*BatchReport, error) {
+	return std.ValidateBatch(r, rules, opts)
+}
+
+// ValidateURL validate URL.
+func ValidateURL(data interface{}, key string, opts URLOptions) (*url.URL, error) {
+	return std.ValidateURL(data, key, opts)
+}
+
+// ValidateURLp validate URL with custom error info.
+// if err != nil will panic.
+func ValidateURLp(data interface{}, key string, opts URLOptions, code int, message string) *url.URL {
+	return std.ValidateURLp(data, key, opts, code, message)
+}
+
+// ValidateRedirect validate redirect target.
+func ValidateRedirect(data interface{}, key string, opts RedirectOptions) (string, error) {
+	return std.ValidateRedirect(data, key, opts)
+}
+
+// ValidateRedirectp validate redirect target with custom error info.
+// if err != nil will panic.
+func ValidateRedirectp(data interface{}, key string, opts RedirectOptions, code int, message string) string {
+	return std.ValidateRedirectp(data, key, opts, code, message)
+}
+
+// ValidateIP validate IP address.
+func ValidateIP(data interface{}, key string, opts IPOptions) (netip.Addr, error) {
+	return std.ValidateIP(data, key, opts)
+}
+
+// ValidateIPp validate IP address with custom error info.
+// if err != nil will panic.
+func ValidateIPp(data interface{}, key string, opts IPOptions, code int, message string) netip.Addr {
+	return std.ValidateIPp(data, key, opts, code, message)
+}
+
+// ValidateCIDR validate IP prefix in CIDR notation.
+func ValidateCIDR(data interface{}, key string, opts IPOptions) (netip.Prefix, error) {
+	return std.ValidateCIDR(data, key, opts)
+}
+
+// ValidateCIDRp validate IP prefix in CIDR notation with custom error info.
+// if err != nil will panic.
+func ValidateCIDRp(data interface{}, key string, opts IPOptions, code int, message string) netip.Prefix {
+	return std.ValidateCIDRp(data, key, opts, code, message)
+}
+
+// ValidateHostname validate host name.
+func ValidateHostname(data interface{}, key string, opts HostnameOptions) (string, error) {
+	return std.ValidateHostname(data, key, opts)
+}
+
+// ValidateHostnamep validate host name with custom error info.
+// if err != nil will panic.
+func ValidateHostnamep(data interface{}, key string, opts HostnameOptions, code int, message string) string {
+	return std.ValidateHostnamep(data, key, opts, code, message)
+}
+
+// ValidateHostPort validate host and port.
+func ValidateHostPort(data interface{}, key string, opts HostPortOptions) (string, int, error) {
+	return std.ValidateHostPort(data, key, opts)
+}
+
+// ValidateHostPortp validate host and port with custom error info.
+// if err != nil will panic.
+func ValidateHostPortp(data interface{}, key string, opts HostPortOptions, code int, message string) (string, int) {
+	return std.ValidateHostPortp(data, key, opts, code, message)
+}
+
+// ValidateEndpoints validate comma-separated endpoints.
+func ValidateEndpoints(data interface{}, key string, opts HostPortOptions) ([]HostPort, error) {
+	return std.ValidateEndpoints(data, key, opts)
+}
+
+// ValidateEndpointsp validate comma-separated endpoints with custom error info.
+// if err != nil will panic.
+func ValidateEndpointsp(data interface{}, key string, opts HostPortOptions, code int, message string) []HostPort {
+	return std.ValidateEndpointsp(data, key, opts, code, message)
+}
+
+// ValidateDomain validate domain.
+func ValidateDomain(data interface{}, key string, opts DomainOptions) (string, error) {
+	return std.ValidateDomain(data, key, opts)
+}
+
+// ValidateDomainp validate domain with custom error info.
+// if err != nil will panic.
+func ValidateDomainp(data interface{}, key string, opts DomainOptions, code int, message string) string {
+	return std.ValidateDomainp(data, key, opts, code, message)
+}
+
+// ValidatePhone validate phone number.
+func ValidatePhone(data interface{}, key, defaultRegion string) (string, error) {
+	return std.ValidatePhone(data, key, defaultRegion)
+}
+
+// ValidatePhonep validate phone number with custom error info.
+// if err != nil will panic.
+func ValidatePhonep(data interface{}, key, defaultRegion string, code int, message string) string {
+	return std.ValidatePhonep(data, key, defaultRegion, code, message)
+}
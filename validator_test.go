@@ -0,0 +1,57 @@
+package vvalidator
+
+import (
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	params := map[string]string{
+		"uid":      " 123 ",
+		"nickname": "fengmoti",
+	}
+
+	v := New()
+	v.Code = 422
+	v.Trim = true
+	v.Strict = true
+	v.Messages[MsgMax] = "{field} must be at most {max}"
+	v.RegisterRule("nick", func(value, param string) bool { return value == "fengmoti" })
+
+	uid, err := v.ValidateInt(params, "uid", 0, 200)
+	equal(t, 123, uid)
+	equal(t, nil, err)
+	_, err = v.ValidateInt(params, "uid", 0, 10, 1)
+	equal(t, "uid must be at most 10", err.Error())
+	equal(t, 422, err.(Error).Code)
+	equal(t, nil, v.ValidateRule(params, "nickname", "nick"))
+	equal(t, "foo is not allowed", v.ValidateRules(map[string]string{"foo": "1"}, map[string]string{}).Error())
+
+	// the default validator is not affected
+	uid, err = ValidateInt(params, "uid", 0, 10, 1)
+	equal(t, 1, uid)
+	equal(t, nil, err)
+	_, err = ValidateInt(params, "uid", 0, 200)
+	equal(t, "uid must be an integer", err.Error())
+	equal(t, DefaultCode, err.(Error).Code)
+	equal(t, "unknown rule nick", ValidateRule(params, "nickname", "nick").Error())
+
+	// Errors panic with all their fields
+	func() {
+		defer func() {
+			es := recover().(Errors)
+			equal(t, 2, len(es))
+			equal(t, []string{"name", "uid"}, []string{es[0].Field, es[1].Field})
+			equal(t, []int{409, 409}, []int{es[0].Code, es[1].Code})
+			equal(t, []string{"name is invalid", "uid is invalid"}, []string{es[0].CustomMessage, es[1].CustomMessage})
+		}()
+		v.ValidateRulesp(params, map[string]string{"uid": "int|max:10", "nickname": "nick", "name": "required"}, 409, "{field} is invalid")
+	}()
+
+	// the code of the *p functions is used as given
+	defer func() {
+		e := recover().(Error)
+		equal(t, 0, e.Code)
+		equal(t, "uid too big", e.CustomMessage)
+	}()
+	v.ValidateIntp(params, "uid", 0, 10, 0, "uid too big")
+}
@@ -0,0 +1,90 @@
+package a
+
+import (
+	"errors"
+
+	"github.com/syyongx/vvalidator"
+)
+
+const maxAge = 10
+
+func bounds(params map[string]string) error {
+	if _, err := vvalidator.ValidateInt(params, "age", 18, maxAge); err != nil { // want `ValidateInt min bound 18 is greater than max bound 10, no value is valid`
+		return err
+	}
+	if _, err := vvalidator.ValidateInt(params, "age", 18, -1); err != nil {
+		return err
+	}
+	if _, err := vvalidator.ValidateFloat(params, "ratio", 0.5, 0.25); err != nil { // want `ValidateFloat min bound 0.5 is greater than max bound 0.25`
+		return err
+	}
+	if _, err := vvalidator.ValidateSlice(params, "ids", ",", 5, 1); err != nil { // want `ValidateSlice min bound 5 is greater`
+		return err
+	}
+	if _, err := vvalidator.New().ValidateInt(params, "age", 3, 2); err != nil { // want `ValidateInt min bound 3 is greater`
+		return err
+	}
+	n := 1
+	_, err := vvalidator.ValidateString(params, "name", 3, n)
+	return err
+}
+
+func patterns(params map[string]string) error {
+	if _, err := vvalidator.ValidateStringWithPattern(params, "code", `^[a-z+$`); err != nil { // want `invalid pattern passed to ValidateStringWithPattern: error parsing regexp`
+		return err
+	}
+	_, err := vvalidator.ValidateStringWithPattern(params, "code", `^[a-z]+$`)
+	return err
+}
+
+func hashes(s string) bool {
+	return vvalidator.IsHash(s, "sha256") || vvalidator.IsHash(s, "SHA1") ||
+		vvalidator.IsHash(s, "sha3-256") // want `IsHash does not support the "sha3-256" algorithm`
+}
+
+func ignored(params map[string]string, s interface{}) {
+	vvalidator.ValidateRule(params, "id", "required")       // want `error returned by ValidateRule is not checked`
+	vvalidator.New().ValidateRule(params, "id", "int")      // want `error returned by ValidateRule is not checked`
+	_ = vvalidator.ValidateStruct(s)                        // want `error returned by ValidateStruct is not checked`
+	age, _ := vvalidator.ValidateInt(params, "age", 0, 150) // want `error returned by ValidateInt is not checked`
+	_ = age
+}
+
+func unrecovered(params map[string]string) int {
+	vvalidator.ValidateTimep(params, "at")
+	vvalidator.ValidateRulep(params, "id", "required", 400, "")          // want `ValidateRulep panics on invalid input outside a function that recovers`
+	return vvalidator.New().ValidateIntp(params, "age", 0, 150, 400, "") // want `ValidateIntp panics`
+}
+
+func recovers(params map[string]string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("invalid")
+		}
+	}()
+	vvalidator.ValidateRulep(params, "id", "required", 400, "")
+	func() {
+		vvalidator.ValidateIntp(params, "age", 0, 150, 400, "")
+	}()
+	go func() {
+		vvalidator.ValidateIntp(params, "age", 0, 150, 400, "") // want `ValidateIntp panics`
+	}()
+	return nil
+}
+
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		*err = errors.New("invalid")
+	}
+}
+
+func recoversWithHelper(params map[string]string) (err error) {
+	defer recoverError(&err)
+	vvalidator.ValidateStringWithPatternp(params, "code", `(`, 400, "") // want `invalid pattern passed to ValidateStringWithPatternp`
+	return nil
+}
+
+//vvalidator:recovered
+func handler(params map[string]string) {
+	vvalidator.ValidateIntp(params, "age", 150, 0, 400, "") // want `ValidateIntp min bound 150 is greater`
+}
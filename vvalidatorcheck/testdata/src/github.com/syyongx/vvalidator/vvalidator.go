@@ -0,0 +1,57 @@
+// Package vvalidator is a stub of the vvalidator API for the analyzer tests.
+package vvalidator
+
+type Validator struct{}
+
+func New() *Validator { return &Validator{} }
+
+var std = New()
+
+func (v *Validator) panicError(err error, code int, message string) { panic(err) }
+
+func (v *Validator) ValidateInt(data interface{}, key string, min, max int, def ...int) (int, error) {
+	return 0, nil
+}
+func (v *Validator) ValidateIntp(data interface{}, key string, min, max int, code int, message string, def ...int) int {
+	value, err := v.ValidateInt(data, key, min, max, def...)
+	v.panicError(err, code, message)
+	return value
+}
+func (v *Validator) ValidateRule(data interface{}, key, rule string) error { return nil }
+func (v *Validator) ValidateRulep(data interface{}, key, rule string, code int, message string) {
+	v.panicError(v.ValidateRule(data, key, rule), code, message)
+}
+func (v *Validator) ValidateStringWithPatternp(data interface{}, key, pattern string, code int, message string, def ...string) string {
+	v.panicError(nil, code, message)
+	return ""
+}
+
+func ValidateInt(data interface{}, key string, min, max int, def ...int) (int, error) { return 0, nil }
+func ValidateIntp(data interface{}, key string, min, max int, code int, message string, def ...int) int {
+	return std.ValidateIntp(data, key, min, max, code, message, def...)
+}
+func ValidateFloat(data interface{}, key string, min, max float64, def ...float64) (float64, error) {
+	return 0, nil
+}
+func ValidateString(data interface{}, key string, min, max int, def ...string) (string, error) {
+	return "", nil
+}
+func ValidateStringWithPattern(data interface{}, key, pattern string, def ...string) (string, error) {
+	return "", nil
+}
+func ValidateStringWithPatternp(data interface{}, key, pattern string, code int, message string, def ...string) string {
+	return std.ValidateStringWithPatternp(data, key, pattern, code, message, def...)
+}
+func ValidateSlice(data interface{}, key, sep string, min, max int, def ...string) ([]string, error) {
+	return nil, nil
+}
+func ValidateRule(data interface{}, key, rule string) error { return nil }
+func ValidateRulep(data interface{}, key, rule string, code int, message string) {
+	std.ValidateRulep(data, key, rule, code, message)
+}
+func ValidateStruct(s interface{}) error { return nil }
+
+// ValidateTimep does not panic despite its name.
+func ValidateTimep(data interface{}, key string) string { return "" }
+
+func IsHash(str, algorithm string) bool { return false }
@@ -0,0 +1,376 @@
+// Package vvalidatorcheck defines an analyzer that reports misuses of the
+// vvalidator API:
+//
+//   - constant min and max bounds with min > max, which no value can satisfy
+//   - pattern literals passed to ValidateStringWithPattern that do not compile
+//   - IsHash called with an unsupported algorithm literal, which is always false
+//   - ignored error returns of the Validate* functions
+//   - *p functions called outside a function that defers a recover, their
+//     Error panic then crashes the program
+//
+// The *p functions are found by their bodies, when the analyzer runs on the
+// vvalidator package: those panicking with an Error, directly or through
+// another function of the package, are marked with a fact.
+//
+// Functions protected by a recovering middleware, such as the grpcvalidator
+// interceptors, are marked with a directive in their doc comment:
+//
+//	//vvalidator:recovered
+//	func (s *server) Echo(ctx context.Context, req *pb.EchoRequest) (*pb.EchoReply, error) {
+//
+// Run it with go vet:
+//
+//	go install github.com/syyongx/vvalidator/cmd/vvalidator-vet
+//	go vet -vettool=$(which vvalidator-vet) ./...
+package vvalidatorcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"github.com/syyongx/vvalidator"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const pkgPath = "github.com/syyongx/vvalidator"
+
+// Directive marks a function whose panics are recovered by its caller.
+const Directive = "//vvalidator:recovered"
+
+// Analyzer reports misuses of the vvalidator API.
+var Analyzer = &analysis.Analyzer{
+	Name:      "vvalidator",
+	Doc:       "report misuses of the vvalidator API: impossible bounds, invalid patterns and hash algorithms, ignored errors and unrecovered *p panics",
+	URL:       "https://pkg.go.dev/github.com/syyongx/vvalidator/vvalidatorcheck",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(panicsFact)},
+}
+
+// panicsFact marks a vvalidator function or *Validator method that panics
+// with an Error instead of returning it.
+type panicsFact struct{}
+
+func (*panicsFact) AFact() {}
+
+func (*panicsFact) String() string { return "panics" }
+
+// bounds are the argument indexes of the min and max bounds by function.
+var bounds = map[string][2]int{
+	"ValidateInt":    {2, 3},
+	"ValidateInt64":  {2, 3},
+	"ValidateFloat":  {2, 3},
+	"ValidateString": {2, 3},
+	"ValidateSlice":  {3, 4},
+}
+
+// hashAlgorithms are the algorithms supported by IsHash.
+var hashAlgorithms = make(map[string]bool)
+
+func init() {
+	for _, algo := range vvalidator.HashAlgorithms() {
+		hashAlgorithms[algo] = true
+	}
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	recovers := recoveringFuncs(pass)
+	if pass.Pkg.Path() == pkgPath {
+		exportPanics(pass)
+	}
+
+	nodes := []ast.Node{(*ast.CallExpr)(nil), (*ast.ExprStmt)(nil), (*ast.AssignStmt)(nil)}
+	insp.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			checkCall(pass, n, stack, recovers)
+		case *ast.ExprStmt:
+			if call, ok := ast.Unparen(n.X).(*ast.CallExpr); ok {
+				if fn := callee(pass, call); fn != nil && returnsError(fn) {
+					pass.Reportf(call.Pos(), "error returned by %s is not checked", fn.Name())
+				}
+			}
+		case *ast.AssignStmt:
+			checkAssign(pass, n)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// callee returns the vvalidator function or *Validator method called by call.
+func callee(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath {
+		return nil
+	}
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		if named, ok := deref(recv.Type()).(*types.Named); !ok || named.Obj().Name() != "Validator" {
+			return nil
+		}
+	}
+	return fn
+}
+
+func deref(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
+
+// returnsError reports whether fn is a Validate* function whose last
+// result is an error.
+func returnsError(fn *types.Func) bool {
+	res := fn.Type().(*types.Signature).Results()
+	return strings.HasPrefix(fn.Name(), "Validate") && res.Len() > 0 &&
+		types.Identical(res.At(res.Len()-1).Type(), types.Universe.Lookup("error").Type())
+}
+
+// panics reports whether fn panics with an Error instead of returning it,
+// as marked by exportPanics.
+func panics(pass *analysis.Pass, fn *types.Func) bool {
+	return pass.ImportObjectFact(fn, new(panicsFact))
+}
+
+// exportPanics marks the exported functions and *Validator methods of the
+// vvalidator package that call the panicError method, directly or through
+// other functions of the package.
+func exportPanics(pass *analysis.Pass) {
+	named, ok := pass.Pkg.Scope().Lookup("Validator").(*types.TypeName)
+	if !ok {
+		return
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named.Type()), false, pass.Pkg, "panicError")
+	panicError, ok := obj.(*types.Func)
+	if !ok {
+		return
+	}
+
+	calls := make(map[*types.Func][]*types.Func)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if f, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func); ok && f.Pkg() == pass.Pkg {
+						calls[fn] = append(calls[fn], f)
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	marked := map[*types.Func]bool{panicError: true}
+	for changed := true; changed; {
+		changed = false
+		for fn, callees := range calls {
+			if marked[fn] {
+				continue
+			}
+			for _, f := range callees {
+				if marked[f] {
+					marked[fn], changed = true, true
+					break
+				}
+			}
+		}
+	}
+	for fn := range marked {
+		if fn != panicError && fn.Exported() {
+			pass.ExportObjectFact(fn, new(panicsFact))
+		}
+	}
+}
+
+func checkCall(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node, recovers map[*types.Func]bool) {
+	fn := callee(pass, call)
+	if fn == nil {
+		return
+	}
+	name, base := fn.Name(), fn.Name()
+	if panics(pass, fn) {
+		// The arguments of a *p function are those of its error variant.
+		base = strings.TrimSuffix(name, "p")
+		// The vvalidator wrappers propagate their panics on purpose.
+		if pass.Pkg.Path() != pkgPath && !recovered(pass, stack, recovers) {
+			pass.Reportf(call.Pos(), "%s panics on invalid input outside a function that recovers; defer a recover, mark the handler %s or use %s", name, Directive, base)
+		}
+	}
+
+	switch {
+	case base == "IsHash" && len(call.Args) == 2:
+		if algo, ok := stringConst(pass, call.Args[1]); ok && !hashAlgorithms[strings.ToLower(algo)] {
+			pass.Reportf(call.Args[1].Pos(), "IsHash does not support the %q algorithm, it always returns false", algo)
+		}
+	case base == "ValidateStringWithPattern" && len(call.Args) > 2:
+		if pattern, ok := stringConst(pass, call.Args[2]); ok {
+			if _, err := regexp.Compile(pattern); err != nil {
+				pass.Reportf(call.Args[2].Pos(), "invalid pattern passed to %s: %v", name, err)
+			}
+		}
+	}
+	if idx, ok := bounds[base]; ok && len(call.Args) > idx[1] {
+		min, max := numberConst(pass, call.Args[idx[0]]), numberConst(pass, call.Args[idx[1]])
+		// -1 means no bound.
+		if min != nil && max != nil && !isNoBound(min) && !isNoBound(max) && constant.Compare(min, token.GTR, max) {
+			pass.Reportf(call.Args[idx[0]].Pos(), "%s min bound %s is greater than max bound %s, no value is valid", name, min, max)
+		}
+	}
+}
+
+// checkAssign reports Validate* errors assigned to the blank identifier.
+func checkAssign(pass *analysis.Pass, as *ast.AssignStmt) {
+	if len(as.Rhs) != 1 {
+		return
+	}
+	call, ok := ast.Unparen(as.Rhs[0]).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	fn := callee(pass, call)
+	if fn == nil || !returnsError(fn) || len(as.Lhs) != fn.Type().(*types.Signature).Results().Len() {
+		return
+	}
+	if id, ok := as.Lhs[len(as.Lhs)-1].(*ast.Ident); ok && id.Name == "_" {
+		pass.Reportf(call.Pos(), "error returned by %s is not checked", fn.Name())
+	}
+}
+
+func stringConst(pass *analysis.Pass, e ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[e]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+func numberConst(pass *analysis.Pass, e ast.Expr) constant.Value {
+	tv, ok := pass.TypesInfo.Types[e]
+	if !ok || tv.Value == nil {
+		return nil
+	}
+	switch tv.Value.Kind() {
+	case constant.Int, constant.Float:
+		return tv.Value
+	}
+	return nil
+}
+
+func isNoBound(v constant.Value) bool {
+	return constant.Compare(v, token.EQL, constant.MakeInt64(-1))
+}
+
+// recovered reports whether a function enclosing a call defers a recover
+// or is marked with the directive. The search stops at goroutines, their
+// panics are not recovered by the function starting them.
+func recovered(pass *analysis.Pass, stack []ast.Node, recovers map[*types.Func]bool) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var body *ast.BlockStmt
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			if hasDirective(fn.Doc) {
+				return true
+			}
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		default:
+			continue
+		}
+		if defersRecover(pass, body, recovers) {
+			return true
+		}
+		if i >= 2 {
+			if g, ok := stack[i-2].(*ast.GoStmt); ok && g.Call == stack[i-1] {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+func hasDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == Directive {
+			return true
+		}
+	}
+	return false
+}
+
+// defersRecover reports whether body defers a call to a function that
+// calls recover, either a function literal or a function of the package.
+func defersRecover(pass *analysis.Pass, body *ast.BlockStmt, recovers map[*types.Func]bool) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Defers of nested functions run when those return.
+			return false
+		case *ast.DeferStmt:
+			switch fn := ast.Unparen(n.Call.Fun).(type) {
+			case *ast.FuncLit:
+				found = found || callsRecover(pass, fn.Body)
+			default:
+				if f, ok := typeutil.Callee(pass.TypesInfo, n.Call).(*types.Func); ok && recovers[f] {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// recoveringFuncs returns the functions of the package that call recover.
+func recoveringFuncs(pass *analysis.Pass) map[*types.Func]bool {
+	funcs := make(map[*types.Func]bool)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			if fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func); ok && callsRecover(pass, fd.Body) {
+				funcs[fn] = true
+			}
+		}
+	}
+	return funcs
+}
+
+func callsRecover(pass *analysis.Pass, body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if b, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Builtin); ok && b.Name() == "recover" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}